@@ -3,9 +3,12 @@ module github.com/ConstantinBalan/bubbletea-init
 go 1.23.0
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/charmbracelet/lipgloss v0.9.1
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/mod v0.21.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -19,5 +22,4 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rivo/uniseg v0.4.4 // indirect
 	golang.org/x/sys v0.13.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -59,9 +59,9 @@ func TestMainFlow(t *testing.T) {
 				// Verify main.go contains bubble components
 				content, err := os.ReadFile(mainFile)
 				require.NoError(t, err)
-				assert.Contains(t, string(content), "type spinner struct")
-				assert.Contains(t, string(content), "type textInput struct")
-				assert.Contains(t, string(content), "github.com/charmbracelet/lipgloss")
+				assert.Contains(t, string(content), "github.com/charmbracelet/bubbles/spinner")
+				assert.Contains(t, string(content), "github.com/charmbracelet/bubbles/textinput")
+				assert.Contains(t, string(content), "\"github.com/yourusername/testbubbles/theme\"")
 			},
 		},
 		{
@@ -0,0 +1,961 @@
+package init
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	teaImportPath  = "github.com/charmbracelet/bubbletea"
+	bubblesModule  = "github.com/charmbracelet/bubbles"
+	bubblesVersion = "v0.18.0"
+
+	lipglossModule  = "github.com/charmbracelet/lipgloss"
+	lipglossVersion = "v0.9.1"
+)
+
+// component describes how a bubbles component is wired into a model.
+//
+// Snippets may use the placeholders {recv} (receiver of the method being
+// edited), {field} and {Field} (the new struct field), {msg} (the message
+// parameter of Update) and {tea} (the local name of the bubbletea import).
+type component struct {
+	pkg     string   // bubbles sub-package providing the Model type
+	imports []string // extra imports needed by the snippets
+	init    string   // expression initialising the field in the constructor
+	initCmd string   // command returned from Init, if any
+	update  string   // statements updating the field and setting {field}Cmd
+	view    string   // expression rendering the field
+	decls   string   // package-level declarations appended to the file
+}
+
+const defaultUpdate = `var {field}Cmd {tea}.Cmd
+{recv}.{field}, {field}Cmd = {recv}.{field}.Update({msg})`
+
+var components = map[string]component{
+	"spinner": {
+		pkg:     "spinner",
+		init:    "spinner.New()",
+		initCmd: "{recv}.{field}.Tick",
+		update:  defaultUpdate,
+		view:    "{recv}.{field}.View()",
+	},
+	"textinput": {
+		pkg:     "textinput",
+		init:    "new{Field}()",
+		initCmd: "textinput.Blink",
+		update:  defaultUpdate,
+		view:    "{recv}.{field}.View()",
+		decls: `func new{Field}() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "Type something..."
+	ti.Focus()
+	return ti
+}`,
+	},
+	"textarea": {
+		pkg:     "textarea",
+		init:    "new{Field}()",
+		initCmd: "textarea.Blink",
+		update:  defaultUpdate,
+		view:    "{recv}.{field}.View()",
+		decls: `func new{Field}() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = "Write something..."
+	ta.Focus()
+	return ta
+}`,
+	},
+	"list": {
+		pkg:    "list",
+		init:   "new{Field}()",
+		update: defaultUpdate,
+		view:   "{recv}.{field}.View()",
+		decls: `func new{Field}() list.Model {
+	l := list.New(nil, list.NewDefaultDelegate(), 40, 10)
+	// The model's own keys quit the program; q and esc stay free for it.
+	l.DisableQuitKeybindings()
+	return l
+}`,
+	},
+	"table": {
+		pkg: "table",
+		init: `table.New(
+	table.WithColumns([]table.Column{{Title: "Name", Width: 20}}),
+	table.WithHeight(10),
+	table.WithFocused(true),
+)`,
+		update: defaultUpdate,
+		view:   "{recv}.{field}.View()",
+	},
+	"viewport": {
+		pkg:    "viewport",
+		init:   "viewport.New(80, 10)",
+		update: defaultUpdate,
+		view:   "{recv}.{field}.View()",
+	},
+	"progress": {
+		pkg:  "progress",
+		init: "progress.New(progress.WithDefaultGradient())",
+		// progress.Model.Update returns a tea.Model, unlike the other components.
+		update: `{field}Model, {field}Cmd := {recv}.{field}.Update({msg})
+{recv}.{field} = {field}Model.(progress.Model)`,
+		view: "{recv}.{field}.View()",
+	},
+	"paginator": {
+		pkg:    "paginator",
+		init:   "paginator.New()",
+		update: defaultUpdate,
+		view:   "{recv}.{field}.View()",
+	},
+	"help": {
+		pkg:     "help",
+		imports: []string{bubblesModule + "/key"},
+		init:    "help.New()",
+		view:    "{recv}.{field}.View({field}Keys)",
+		decls: `// {field}KeyMap lists the bindings shown by the {field} component.
+type {field}KeyMap struct {
+	Quit key.Binding
+}
+
+func (k {field}KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Quit}
+}
+
+func (k {field}KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+var {field}Keys = {field}KeyMap{
+	Quit: key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+}`,
+	},
+	"filepicker": {
+		pkg:     "filepicker",
+		init:    "new{Field}()",
+		initCmd: "{recv}.{field}.Init()",
+		update:  defaultUpdate,
+		view:    "{recv}.{field}.View()",
+		decls: `func new{Field}() filepicker.Model {
+	fp := filepicker.New()
+	fp.CurrentDirectory = "."
+	return fp
+}`,
+	},
+	"timer": {
+		pkg:     "timer",
+		imports: []string{"time"},
+		init:    "timer.New(time.Minute)",
+		initCmd: "{recv}.{field}.Init()",
+		update:  defaultUpdate,
+		view:    "{recv}.{field}.View()",
+	},
+	"stopwatch": {
+		pkg:     "stopwatch",
+		init:    "stopwatch.New()",
+		initCmd: "{recv}.{field}.Init()",
+		update:  defaultUpdate,
+		view:    "{recv}.{field}.View()",
+	},
+}
+
+func componentNames() []string {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runAdd(args []string) {
+	flags := pflag.NewFlagSet("add", pflag.ContinueOnError)
+	dir := flags.StringP("dir", "C", ".", "Directory containing the model to modify")
+	fieldName := flags.String("name", "", "Name of the new model field (default: the component name)")
+	modelName := flags.String("model", "", "Model type to modify (default: the only type with Init, Update and View)")
+	help := flags.BoolP("help", "h", false, "Show help message")
+	flags.Usage = func() { printAddUsage(flags) }
+
+	if err := flags.Parse(args); err != nil {
+		Exit(1)
+	}
+
+	if *help || flags.NArg() < 1 {
+		printAddUsage(flags)
+		Exit(0)
+	}
+
+	name := flags.Arg(0)
+	c, ok := components[name]
+	if !ok {
+		fmt.Printf("Error: unknown component '%s'. Available components: %s\n", name, strings.Join(componentNames(), ", "))
+		Exit(1)
+	}
+
+	field := *fieldName
+	if field == "" {
+		field = name
+	}
+	if !token.IsIdentifier(field) {
+		fmt.Printf("Error: '%s' is not a valid Go field name\n", field)
+		Exit(1)
+	}
+
+	// Everything is checked before anything is written, so that a failure
+	// leaves the project as it was.
+	modFile, err := findGoMod(*dir)
+	if err != nil {
+		fmt.Println("Error:", err)
+		Exit(1)
+	}
+	oldMod, err := os.ReadFile(modFile)
+	if err != nil {
+		fmt.Println("Error reading go.mod:", err)
+		Exit(1)
+	}
+	newMod, err := withRequirement(modFile, oldMod, bubblesModule, bubblesVersion)
+	if err != nil {
+		fmt.Println("Error updating go.mod:", err)
+		Exit(1)
+	}
+
+	edit, err := addComponent(*dir, *modelName, field, c)
+	if err != nil {
+		fmt.Println("Error:", err)
+		Exit(1)
+	}
+
+	if !bytes.Equal(newMod, oldMod) {
+		if err := os.WriteFile(modFile, newMod, 0644); err != nil {
+			fmt.Println("Error updating go.mod:", err)
+			Exit(1)
+		}
+	}
+	if err := os.WriteFile(edit.path, edit.src, 0644); err != nil {
+		// Put go.mod back rather than require a module nothing imports.
+		os.WriteFile(modFile, oldMod, 0644)
+		fmt.Printf("Error writing %s: %v\n", edit.path, err)
+		Exit(1)
+	}
+
+	successMsg := style.Render("✅ Added!")
+	fmt.Printf("\n%s Component '%s' added to %s as field '%s'.\n", successMsg, name, edit.path, field)
+	fmt.Println("\nNext steps:")
+	root := filepath.Dir(modFile)
+	if rel := relPath(root); rel != "." {
+		fmt.Printf("  cd %s\n", rel)
+	}
+	fmt.Println("  go mod tidy")
+	fmt.Printf("  go run %s\n", runTarget(root, filepath.Dir(edit.path), edit.main))
+}
+
+// relPath returns dir relative to the current directory, or dir itself if
+// it can't be.
+func relPath(dir string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return dir
+	}
+	rel, err := filepath.Rel(wd, dir)
+	if err != nil {
+		return dir
+	}
+	return rel
+}
+
+// runTarget returns the package to go run, relative to the module root, to
+// try the edited model: its own directory if it is a main package, or else
+// the only command under cmd, as in the standard layout.
+func runTarget(root, dir string, main bool) string {
+	if !main {
+		if cmds, _ := filepath.Glob(filepath.Join(root, "cmd", "*", "main.go")); len(cmds) == 1 {
+			dir = filepath.Dir(cmds[0])
+		}
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "."
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == "." {
+		return "."
+	}
+	return "./" + filepath.ToSlash(rel)
+}
+
+func printAddUsage(flags *pflag.FlagSet) {
+	fmt.Println("Usage: bubbletea-init add [flags] <component>")
+	fmt.Println("\nComponents:")
+	for _, name := range componentNames() {
+		fmt.Printf("  %s\n", name)
+	}
+	fmt.Println("\nFlags:")
+	flags.PrintDefaults()
+}
+
+// modelShape is the part of a project that addComponent knows how to edit:
+// a struct type with Init, Update and View methods declared in one file.
+type modelShape struct {
+	path   string
+	src    []byte
+	fset   *token.FileSet
+	file   *ast.File
+	name   string
+	fields *ast.FieldList
+	init   *ast.FuncDecl
+	update *ast.FuncDecl
+	view   *ast.FuncDecl
+}
+
+// modelEdit is a rewritten model file, ready to be written.
+type modelEdit struct {
+	path string
+	src  []byte
+	main bool // whether the file is in package main
+}
+
+// addComponent wires c into the model found in dir and returns the file to
+// rewrite. It writes nothing itself.
+func addComponent(dir, modelName, field string, c component) (modelEdit, error) {
+	shape, globals, err := findModel(dir, modelName)
+	if err != nil {
+		return modelEdit{}, err
+	}
+
+	for _, f := range shape.fields.List {
+		for _, n := range f.Names {
+			if n.Name == field {
+				return modelEdit{}, fmt.Errorf("model '%s' already has a field named '%s'; choose another with --name", shape.name, field)
+			}
+		}
+	}
+
+	teaName, ok := importName(shape.file, teaImportPath)
+	if !ok {
+		return modelEdit{}, fmt.Errorf("%s does not import %s, so its model shape is not recognisable", shape.path, teaImportPath)
+	}
+
+	msgName := ""
+	if params := shape.update.Type.Params.List; len(params[0].Names) == 1 {
+		msgName = params[0].Names[0].Name
+	}
+	if msgName == "" || msgName == "_" {
+		return modelEdit{}, fmt.Errorf("the message parameter of %s.Update must be named so it can be passed to the component", shape.name)
+	}
+
+	fill := func(snippet string, method *ast.FuncDecl) string {
+		recv := ""
+		if method != nil {
+			recv = method.Recv.List[0].Names[0].Name
+		}
+		return strings.NewReplacer(
+			"{recv}", recv,
+			"{field}", field,
+			"{Field}", capitalize(field),
+			"{msg}", msgName,
+			"{tea}", teaName,
+		).Replace(snippet)
+	}
+
+	imports := append([]string{bubblesModule + "/" + c.pkg}, c.imports...)
+	var newImports []string
+	for _, imp := range imports {
+		name := path.Base(imp)
+		if existing, ok := importName(shape.file, imp); ok {
+			if existing != name {
+				return modelEdit{}, fmt.Errorf("%s imports %s as '%s'; remove the alias before adding %s", shape.path, imp, existing, c.pkg)
+			}
+			continue
+		}
+		if globals[name] {
+			return modelEdit{}, fmt.Errorf("'%s' is already declared in this package and would clash with the %s import", name, imp)
+		}
+		newImports = append(newImports, imp)
+	}
+
+	decls := fill(c.decls, nil)
+	if decls != "" {
+		names, err := declaredNames(decls)
+		if err != nil {
+			return modelEdit{}, err
+		}
+		for _, name := range names {
+			if globals[name] {
+				return modelEdit{}, fmt.Errorf("'%s' is already declared in this package; choose another field name with --name", name)
+			}
+		}
+	}
+
+	e := &editor{src: shape.src, fset: shape.fset}
+
+	// Struct field.
+	var lastField ast.Node
+	if n := len(shape.fields.List); n > 0 {
+		lastField = shape.fields.List[n-1]
+	}
+	fieldDecl := fmt.Sprintf("%s %s.Model", field, c.pkg)
+	if lastField == nil {
+		e.insert(shape.fields.Closing, "\n"+fieldDecl+"\n")
+	} else {
+		e.appendItem(lastField, shape.fields.Closing, fieldDecl, "", ";")
+	}
+
+	// Constructor.
+	literals, err := modelLiterals(shape.file, shape.name)
+	if err != nil {
+		return modelEdit{}, err
+	}
+	for _, lit := range literals {
+		kv := fmt.Sprintf("%s: %s", field, fill(c.init, nil))
+		if len(lit.Elts) == 0 {
+			e.insert(lit.Rbrace, "\n"+kv+",\n")
+		} else {
+			e.appendItem(lit.Elts[len(lit.Elts)-1], lit.Rbrace, kv, ",", ",")
+		}
+	}
+
+	// Init.
+	if c.initCmd != "" {
+		cmd := fill(c.initCmd, shape.init)
+		for _, ret := range returns(shape.init) {
+			if len(ret.Results) != 1 {
+				return modelEdit{}, fmt.Errorf("%s.Init must return a single tea.Cmd expression", shape.name)
+			}
+			e.addCmd(ret.Results[0], teaName, cmd)
+		}
+	}
+
+	// Update.
+	if c.update != "" {
+		// Components added earlier come first, so snippets keep the order
+		// the components were added in.
+		if last := lastComponentUpdate(shape.update); last != nil {
+			e.insert(last.End(), "\n"+fill(c.update, shape.update))
+		} else {
+			e.insert(shape.update.Body.Lbrace+1, "\n"+fill(c.update, shape.update)+"\n")
+		}
+		for _, ret := range returns(shape.update) {
+			if len(ret.Results) != 2 {
+				return modelEdit{}, fmt.Errorf("%s.Update must return explicit (tea.Model, tea.Cmd) values", shape.name)
+			}
+			e.addCmd(ret.Results[1], teaName, field+"Cmd")
+		}
+	}
+
+	// View.
+	view := fill(c.view, shape.view)
+	for _, ret := range returns(shape.view) {
+		if len(ret.Results) != 1 {
+			return modelEdit{}, fmt.Errorf("%s.View must return a single string expression", shape.name)
+		}
+		e.insert(ret.Results[0].End(), ` + "\n" + `+view)
+	}
+
+	if decls != "" {
+		e.insert(shape.file.End(), "\n\n"+decls+"\n")
+	}
+	e.addImports(shape.file, newImports)
+
+	out, err := format.Source(e.apply())
+	if err != nil {
+		return modelEdit{}, fmt.Errorf("rewriting %s produced invalid Go: %v", shape.path, err)
+	}
+
+	return modelEdit{path: shape.path, src: out, main: shape.file.Name.Name == "main"}, nil
+}
+
+// findModel parses the Go files in dir and locates the model to edit. It
+// also returns the package-level names declared across those files.
+func findModel(dir, modelName string) (*modelShape, map[string]bool, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fset := token.NewFileSet()
+	globals := map[string]bool{}
+	structs := map[string]*modelShape{}
+	methods := map[string]map[string]*ast.FuncDecl{}
+	methodFiles := map[*ast.FuncDecl]string{}
+
+	for _, p := range paths {
+		if strings.HasSuffix(p, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		file, err := parser.ParseFile(fset, p, src, parser.ParseComments)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing %s: %v", p, err)
+		}
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					globals[d.Name.Name] = true
+					continue
+				}
+				recv := receiverType(d)
+				if recv == "" {
+					continue
+				}
+				if methods[recv] == nil {
+					methods[recv] = map[string]*ast.FuncDecl{}
+				}
+				methods[recv][d.Name.Name] = d
+				methodFiles[d] = p
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						globals[s.Name.Name] = true
+						if st, ok := s.Type.(*ast.StructType); ok {
+							structs[s.Name.Name] = &modelShape{
+								path:   p,
+								src:    src,
+								fset:   fset,
+								file:   file,
+								name:   s.Name.Name,
+								fields: st.Fields,
+							}
+						}
+					case *ast.ValueSpec:
+						for _, n := range s.Names {
+							globals[n.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+
+	var candidates []string
+	for name := range structs {
+		m := methods[name]
+		if m["Init"] != nil && m["Update"] != nil && m["View"] != nil {
+			candidates = append(candidates, name)
+		}
+	}
+	sort.Strings(candidates)
+
+	if modelName == "" {
+		switch len(candidates) {
+		case 0:
+			return nil, nil, fmt.Errorf("no Bubble Tea model found in %s: expected a struct type with Init, Update and View methods", dir)
+		case 1:
+			modelName = candidates[0]
+		default:
+			return nil, nil, fmt.Errorf("found several models (%s); choose one with --model", strings.Join(candidates, ", "))
+		}
+	}
+
+	shape, ok := structs[modelName]
+	m := methods[modelName]
+	if !ok || m["Init"] == nil || m["Update"] == nil || m["View"] == nil {
+		return nil, nil, fmt.Errorf("'%s' is not a struct type with Init, Update and View methods in %s", modelName, dir)
+	}
+
+	shape.init, shape.update, shape.view = m["Init"], m["Update"], m["View"]
+	for _, method := range []*ast.FuncDecl{shape.init, shape.update, shape.view} {
+		if methodFiles[method] != shape.path {
+			return nil, nil, fmt.Errorf("%s.%s is declared in %s, but the type is declared in %s; keep the type and its Init, Update and View methods in one file",
+				modelName, method.Name.Name, methodFiles[method], shape.path)
+		}
+		if len(method.Recv.List[0].Names) == 0 || method.Recv.List[0].Names[0].Name == "_" {
+			return nil, nil, fmt.Errorf("%s.%s needs a named receiver", modelName, method.Name.Name)
+		}
+	}
+
+	if !hasSignature(shape.init, 0, 1) || !hasSignature(shape.update, 1, 2) || !hasSignature(shape.view, 0, 1) {
+		return nil, nil, fmt.Errorf("'%s' does not have the tea.Model method signatures Init() tea.Cmd, Update(tea.Msg) (tea.Model, tea.Cmd) and View() string", modelName)
+	}
+
+	return shape, globals, nil
+}
+
+func receiverType(fd *ast.FuncDecl) string {
+	t := fd.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	if ident, ok := t.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func hasSignature(fd *ast.FuncDecl, params, results int) bool {
+	if fd.Type.Params.NumFields() != params {
+		return false
+	}
+	return fd.Type.Results.NumFields() == results
+}
+
+// modelLiterals returns the composite literals constructing the named type.
+func modelLiterals(file *ast.File, name string) ([]*ast.CompositeLit, error) {
+	var literals []*ast.CompositeLit
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		if ident, ok := lit.Type.(*ast.Ident); ok && ident.Name == name {
+			literals = append(literals, lit)
+		}
+		return true
+	})
+
+	if len(literals) == 0 {
+		return nil, fmt.Errorf("could not find where '%s' is constructed: expected a %s{...} literal, for example in an initialModel function", name, name)
+	}
+	for _, lit := range literals {
+		for _, elt := range lit.Elts {
+			if _, ok := elt.(*ast.KeyValueExpr); !ok {
+				return nil, fmt.Errorf("'%s' is constructed with positional fields; use field names (%s{field: value}) so new fields can be added", name, name)
+			}
+		}
+	}
+	return literals, nil
+}
+
+// returns lists the return statements of fd, ignoring those inside
+// function literals.
+func returns(fd *ast.FuncDecl) []*ast.ReturnStmt {
+	var stmts []*ast.ReturnStmt
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			stmts = append(stmts, s)
+		}
+		return true
+	})
+	return stmts
+}
+
+// importName reports the local name under which file imports importPath.
+func importName(file *ast.File, importPath string) (string, bool) {
+	for _, imp := range file.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || p != importPath {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name, true
+		}
+		return path.Base(p), true
+	}
+	return "", false
+}
+
+func declaredNames(decls string) ([]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package p\n\n"+decls, 0)
+	if err != nil {
+		return nil, err
+	}
+	return fileNames(file), nil
+}
+
+// fileNames returns the package-level names declared in file.
+func fileNames(file *ast.File) []string {
+	var names []string
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				names = append(names, d.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, s.Name.Name)
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						names = append(names, n.Name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+func capitalize(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// editor collects text insertions located with the AST and applies them to
+// the original source, which keeps comments and formatting intact.
+type editor struct {
+	src   []byte
+	fset  *token.FileSet
+	edits []textEdit
+}
+
+// textEdit replaces src[offset:end] with text; end is zero for insertions.
+type textEdit struct {
+	offset int
+	end    int
+	text   string
+}
+
+func (e *editor) offset(pos token.Pos) int {
+	return e.fset.Position(pos).Offset
+}
+
+func (e *editor) insert(pos token.Pos, text string) {
+	e.insertAt(e.offset(pos), text)
+}
+
+func (e *editor) insertAt(offset int, text string) {
+	e.edits = append(e.edits, textEdit{offset: offset, text: text})
+}
+
+// appendItem adds item after last in a list closed at closing. Lists laid
+// out one item per line get a new line; inline lists are extended in place.
+func (e *editor) appendItem(last ast.Node, closing token.Pos, item, lineSep, inlineSep string) {
+	end := e.offset(last.End())
+	if nl := bytes.IndexByte(e.src[end:e.offset(closing)], '\n'); nl >= 0 {
+		e.insertAt(end+nl+1, item+lineSep+"\n")
+		return
+	}
+	e.insertAt(end, inlineSep+" "+item)
+}
+
+// addCmd makes the command expression expr also run cmd.
+func (e *editor) addCmd(expr ast.Expr, teaName, cmd string) {
+	if ident, ok := expr.(*ast.Ident); ok && ident.Name == "nil" {
+		e.edits = append(e.edits, textEdit{offset: e.offset(expr.Pos()), end: e.offset(expr.End()), text: cmd})
+		return
+	}
+
+	if call, ok := expr.(*ast.CallExpr); ok && isBatch(call, teaName) && !call.Ellipsis.IsValid() {
+		if len(call.Args) == 0 {
+			e.insert(call.Rparen, cmd)
+		} else {
+			e.appendItem(call.Args[len(call.Args)-1], call.Rparen, cmd, ",", ",")
+		}
+		return
+	}
+
+	e.insert(expr.Pos(), teaName+".Batch(")
+	e.insert(expr.End(), ", "+cmd+")")
+}
+
+// lastComponentUpdate returns the last of the statements add put at the top
+// of Update for earlier components, or nil if there are none.
+func lastComponentUpdate(update *ast.FuncDecl) ast.Stmt {
+	recv := update.Recv.List[0].Names[0].Name
+	var last ast.Stmt
+	for _, stmt := range update.Body.List {
+		if !isComponentUpdate(stmt, recv) {
+			break
+		}
+		last = stmt
+	}
+	return last
+}
+
+// isComponentUpdate reports whether stmt is one of the statements in an
+// update snippet: the "var <field>Cmd" declaration, the call to the field's
+// Update method, or progress's type assertion of its result.
+func isComponentUpdate(stmt ast.Stmt, recv string) bool {
+	switch s := stmt.(type) {
+	case *ast.DeclStmt:
+		gen, ok := s.Decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR || len(gen.Specs) != 1 {
+			return false
+		}
+		names := gen.Specs[0].(*ast.ValueSpec).Names
+		return len(names) == 1 && strings.HasSuffix(names[0].Name, "Cmd")
+	case *ast.AssignStmt:
+		if len(s.Rhs) != 1 {
+			return false
+		}
+		switch rhs := s.Rhs[0].(type) {
+		case *ast.CallExpr:
+			// {recv}.{field}.Update(msg)
+			sel, ok := rhs.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Update" {
+				return false
+			}
+			field, ok := sel.X.(*ast.SelectorExpr)
+			if !ok {
+				return false
+			}
+			x, ok := field.X.(*ast.Ident)
+			return ok && x.Name == recv
+		case *ast.TypeAssertExpr:
+			// {recv}.{field} = {field}Model.(progress.Model)
+			x, ok := rhs.X.(*ast.Ident)
+			return ok && strings.HasSuffix(x.Name, "Model")
+		}
+	}
+	return false
+}
+
+func isBatch(call *ast.CallExpr, teaName string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Batch" {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == teaName
+}
+
+// addImports adds import paths to the file's import declaration, placing
+// standard library packages after the existing standard library imports and
+// the others at the end of the group holding the bubbletea import, so they
+// stay apart from the project's own packages.
+func (e *editor) addImports(file *ast.File, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+
+	var gen *ast.GenDecl
+	for _, decl := range file.Decls {
+		if d, ok := decl.(*ast.GenDecl); ok && d.Tok == token.IMPORT {
+			gen = d
+			break
+		}
+	}
+	if gen == nil {
+		return
+	}
+
+	if !gen.Lparen.IsValid() {
+		// Turn a single-line import into a block.
+		var lines strings.Builder
+		for _, p := range paths {
+			lines.WriteString("\t" + strconv.Quote(p) + "\n")
+		}
+		e.insert(gen.Specs[0].Pos(), "(\n\t")
+		e.insert(gen.Specs[0].End(), "\n"+lines.String()+")")
+		return
+	}
+
+	last := gen.Specs[len(gen.Specs)-1]
+	lastStd := last
+	for _, spec := range gen.Specs {
+		if p, err := strconv.Unquote(spec.(*ast.ImportSpec).Path.Value); err == nil && isStdlib(p) {
+			lastStd = spec
+		}
+	}
+	for i, spec := range gen.Specs {
+		if p, err := strconv.Unquote(spec.(*ast.ImportSpec).Path.Value); err != nil || p != teaImportPath {
+			continue
+		}
+		// Groups are separated by blank lines.
+		last = spec
+		for _, next := range gen.Specs[i+1:] {
+			if e.fset.Position(next.Pos()).Line != e.fset.Position(last.End()).Line+1 {
+				break
+			}
+			last = next
+		}
+		break
+	}
+	for _, p := range paths {
+		after := last
+		if isStdlib(p) {
+			after = lastStd
+		}
+		e.appendItem(after, gen.Rparen, "\t"+strconv.Quote(p), "", ";")
+	}
+}
+
+func isStdlib(importPath string) bool {
+	return !strings.Contains(strings.Split(importPath, "/")[0], ".")
+}
+
+// apply returns the source with all edits applied. Edits at the same offset
+// keep the order in which they were added.
+func (e *editor) apply() []byte {
+	edits := append([]textEdit(nil), e.edits...)
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].offset < edits[j].offset })
+
+	var out bytes.Buffer
+	last := 0
+	for _, ed := range edits {
+		out.Write(e.src[last:ed.offset])
+		out.WriteString(ed.text)
+		last = max(ed.offset, ed.end)
+	}
+	out.Write(e.src[last:])
+	return out.Bytes()
+}
+
+// findGoMod returns the go.mod governing dir, searching parent directories.
+func findGoMod(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for d := abs; ; d = filepath.Dir(d) {
+		p := filepath.Join(d, "go.mod")
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+		if filepath.Dir(d) == d {
+			return "", fmt.Errorf("no go.mod found in %s or its parent directories", abs)
+		}
+	}
+}
+
+// addRequirement adds a require directive to a go.mod file unless the module
+// is already required.
+func addRequirement(modPath, module, version string) error {
+	content, err := os.ReadFile(modPath)
+	if err != nil {
+		return err
+	}
+	out, err := withRequirement(modPath, content, module, version)
+	if err != nil || bytes.Equal(out, content) {
+		return err
+	}
+	return os.WriteFile(modPath, out, 0644)
+}
+
+// withRequirement returns content, the go.mod at modPath, with a require
+// directive for module added unless it is already required.
+func withRequirement(modPath string, content []byte, module, version string) ([]byte, error) {
+	f, err := modfile.Parse(modPath, content, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range f.Require {
+		if r.Mod.Path == module {
+			return content, nil
+		}
+	}
+	if f.Module == nil {
+		return nil, errors.New("go.mod has no module directive")
+	}
+
+	if err := f.AddRequire(module, version); err != nil {
+		return nil, err
+	}
+	return f.Format()
+}
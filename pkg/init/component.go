@@ -0,0 +1,199 @@
+package init
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/mod/modfile"
+)
+
+//go:embed templates/component/model.go.tmpl
+var componentModelTemplate string
+
+//go:embed templates/component/keys.go.tmpl
+var componentKeysTemplate string
+
+//go:embed templates/component/model_test.go.tmpl
+var componentTestTemplate string
+
+type componentData struct {
+	Name       string
+	Package    string
+	ImportPath string
+	ThemePath  string // import path of the project's theme package, if any
+}
+
+func runNew(args []string) {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		printNewUsage(nil)
+		Exit(0)
+	}
+	if args[0] != "component" {
+		fmt.Printf("Error: unknown generator '%s'; only 'component' is supported\n", args[0])
+		Exit(1)
+	}
+
+	flags := pflag.NewFlagSet("new component", pflag.ContinueOnError)
+	pkgDir := flags.String("pkg", "", "Package directory relative to the module root (default: internal/ui/<name>)")
+	dir := flags.StringP("dir", "C", ".", "Directory inside the project whose go.mod should be used")
+	force := flags.Bool("force", false, "Overwrite existing files")
+	help := flags.BoolP("help", "h", false, "Show help message")
+	flags.Usage = func() { printNewUsage(flags) }
+
+	if err := flags.Parse(args[1:]); err != nil {
+		Exit(1)
+	}
+
+	if *help || flags.NArg() < 1 {
+		printNewUsage(flags)
+		Exit(0)
+	}
+
+	name := flags.Arg(0)
+	if !token.IsIdentifier(name) {
+		fmt.Printf("Error: '%s' is not a valid component name; use a Go identifier such as Sidebar\n", name)
+		Exit(1)
+	}
+
+	modPath, err := findGoMod(*dir)
+	if err != nil {
+		fmt.Println("Error:", err)
+		Exit(1)
+	}
+	modName, err := modulePath(modPath)
+	if err != nil {
+		fmt.Println("Error reading go.mod:", err)
+		Exit(1)
+	}
+
+	rel := *pkgDir
+	if rel == "" {
+		rel = path.Join("internal", "ui", strings.ToLower(name))
+	}
+	rel = path.Clean(filepath.ToSlash(rel))
+	if path.IsAbs(rel) || strings.HasPrefix(rel, "..") {
+		fmt.Printf("Error: --pkg must be a directory inside the module, got '%s'\n", *pkgDir)
+		Exit(1)
+	}
+
+	pkgName := strings.ToLower(path.Base(rel))
+	if !token.IsIdentifier(pkgName) || token.IsKeyword(pkgName) {
+		fmt.Printf("Error: '%s' is not a valid Go package name; choose another --pkg\n", path.Base(rel))
+		Exit(1)
+	}
+
+	data := componentData{
+		Name:       name,
+		Package:    pkgName,
+		ImportPath: path.Join(modName, rel),
+	}
+
+	root := filepath.Dir(modPath)
+	// Components take their styles from the theme when there is one.
+	if dir, err := findThemePackage(root); err == nil {
+		if themeRel, err := filepath.Rel(root, dir); err == nil {
+			data.ThemePath = path.Join(modName, filepath.ToSlash(themeRel))
+		}
+	}
+
+	outDir := filepath.Join(root, filepath.FromSlash(rel))
+	files := map[string]string{
+		pkgName + ".go":      componentModelTemplate,
+		"keymap.go":          componentKeysTemplate,
+		pkgName + "_test.go": componentTestTemplate,
+	}
+
+	if !*force {
+		for file := range files {
+			if _, err := os.Stat(filepath.Join(outDir, file)); err == nil {
+				fmt.Printf("Error: %s already exists. Use --force to overwrite.\n", filepath.Join(outDir, file))
+				Exit(1)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		fmt.Printf("Error creating package directory '%s': %v\n", outDir, err)
+		Exit(1)
+	}
+
+	for file, content := range files {
+		out, err := renderGo(file, content, data)
+		if err != nil {
+			fmt.Println("Error:", err)
+			Exit(1)
+		}
+		if err := os.WriteFile(filepath.Join(outDir, file), out, 0644); err != nil {
+			fmt.Printf("Error writing %s: %v\n", file, err)
+			Exit(1)
+		}
+	}
+
+	for _, req := range []struct{ module, version string }{
+		{bubblesModule, bubblesVersion},
+		{lipglossModule, lipglossVersion},
+	} {
+		if err := addRequirement(modPath, req.module, req.version); err != nil {
+			fmt.Println("Error updating go.mod:", err)
+			Exit(1)
+		}
+	}
+
+	successMsg := style.Render("✅ Success!")
+	fmt.Printf("\n%s Component '%s' created in %s\n", successMsg, name, outDir)
+	fmt.Println("\nUse it from your model:")
+	fmt.Printf("  import \"%s\"\n", data.ImportPath)
+	fmt.Printf("  %s.New(%s.WithItems(\"first\", \"second\"))\n", pkgName, pkgName)
+	fmt.Println("\nNext steps:")
+	fmt.Println("  go mod tidy")
+	fmt.Printf("  go test ./%s\n", rel)
+}
+
+func printNewUsage(flags *pflag.FlagSet) {
+	fmt.Println("Usage: bubbletea-init new component [flags] <Name>")
+	if flags != nil {
+		fmt.Println("\nFlags:")
+		flags.PrintDefaults()
+	}
+}
+
+// renderGo executes a template producing Go source and gofmts the result.
+func renderGo(name, content string, data any) ([]byte, error) {
+	tmpl, err := template.New(name).Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template for %s: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("executing template for %s: %v", name, err)
+	}
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting %s: %v", name, err)
+	}
+	return out, nil
+}
+
+// modulePath returns the module path declared in a go.mod file.
+func modulePath(modPath string) (string, error) {
+	content, err := os.ReadFile(modPath)
+	if err != nil {
+		return "", err
+	}
+	name := modfile.ModulePath(content)
+	if name == "" {
+		return "", fmt.Errorf("%s has no module directive", modPath)
+	}
+	return name, nil
+}
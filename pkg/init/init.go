@@ -4,8 +4,12 @@ import (
 	"bytes"
 	_ "embed"
 	"fmt"
+	"go/format"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 	"text/template"
 
 	"github.com/charmbracelet/lipgloss"
@@ -21,8 +25,39 @@ var mainTemplate string
 //go:embed templates/main_with_bubbles.go.tmpl
 var bubblesTemplate string
 
+//go:embed templates/main_no_deps.go.tmpl
+var noDepsTemplate string
+
 type templateData struct {
 	ProjectName string
+	ModulePath  string
+	Package     string // package of files shared between layouts
+	Layout      string
+	EnvPrefix   string
+	AltScreen   bool
+	Mouse       string // "", "cell" or "all"
+	ReportFocus bool
+	// ProgramOptions are the tea.ProgramOption expressions passed to
+	// tea.NewProgram.
+	ProgramOptions []string
+	Keys           *keyMapSpec
+	Theme          string // name of the default palette
+	ThemePath      string // import path of the theme package
+	Bubbles        bool   // the project depends on charmbracelet/bubbles
+	CLI            bool   // main parses flags and falls back to plain text
+	Debug          bool   // main logs to a file and wraps the model in a log pane
+	ViewLayout     string // "", "stacked" or "split"
+	Form           bool   // the multi-screen template has a form screen
+	Palette        bool   // the default template has a command palette
+	Overlays       bool   // the default or multi-screen template has dialogs and toasts
+	OverlayPath    string // import path of the overlay package
+	Editor         bool   // the todo or file-browser template opens $EDITOR and $PAGER
+	// The file-browser template's defaults: the extensions it selects, or
+	// any if empty, whether it lists hidden files and what it does with a
+	// selected file ("print" or "status").
+	Extensions []string
+	ShowHidden bool
+	OnSelect   string
 }
 
 var (
@@ -35,7 +70,38 @@ var (
 )
 
 func Initialize() {
+	if len(os.Args) > 1 && os.Args[1] == "add" {
+		runAdd(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "new" {
+		runNew(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "theme" {
+		runTheme(os.Args[2:])
+		return
+	}
+
 	withBubbles := pflag.Bool("with-bubbles", false, "Include example bubble components (spinner, textinput)")
+	noDeps := pflag.Bool("no-deps", false, "Use hand-rolled educational components instead of charmbracelet/bubbles (with --with-bubbles)")
+	templateName := pflag.StringP("template", "t", "default", "Project template to generate (see Templates below)")
+	layout := pflag.String("layout", "flat", "Project layout: flat (single package) or standard (cmd/, internal/ui, internal/app, internal/config)")
+	themeName := pflag.String("theme", themes[0], "Color palette: "+strings.Join(themes, ", "))
+	altScreen := pflag.Bool("alt-screen", false, "Run the program in the terminal's alternate screen (default for the multi-screen template)")
+	inline := pflag.Bool("inline", false, "Render inline below the prompt instead of in the alternate screen")
+	mouse := pflag.String("mouse", "", "Enable mouse events: cell (clicks, wheel and drags) or all (every motion)")
+	reportFocus := pflag.Bool("report-focus", false, "Send focus and blur messages when the terminal window gains or loses focus")
+	viewLayout := pflag.String("view-layout", "", "Responsive view for the default template: stacked (header, body, footer) or split (sidebar and main pane)")
+	withForm := pflag.Bool("with-form", false, "Add a screen with a charmbracelet/huh form to the multi-screen template")
+	withPalette := pflag.Bool("with-command-palette", false, "Add a fuzzy command palette, opened with ctrl+p, and a registry of named actions to the default template")
+	withOverlays := pflag.Bool("with-overlays", false, "Add an overlay package with modal dialogs (confirm, prompt, error) and toast notifications to the default and multi-screen templates")
+	withEditor := pflag.Bool("with-editor", false, "Open the selected todo's notes or file in $EDITOR (E) and $PAGER (v) in the todo and file-browser templates")
+	extensions := pflag.StringSlice("extensions", nil, "File extensions the file-browser template can select, e.g. .go,.md (default: any file)")
+	showHidden := pflag.Bool("show-hidden", false, "List hidden files from the start in the file-browser template")
+	onSelect := pflag.String("on-select", "print", "What the file-browser template does with a selected file: print (quit and print its path) or status (report it and keep browsing)")
+	debug := pflag.Bool("debug", false, "Add debug logging with slog through tea.LogToFile, enabled by <NAME>_DEBUG or --debug, and a log pane toggled with f12")
+	cli := pflag.Bool("cli", false, "Generate a CLI entrypoint with --version, --debug logging and a plain-text fallback when not run in a terminal")
 	modPath := pflag.String("mod", "", "Custom Go module name")
 	outputDir := pflag.StringP("output-dir", "o", "", "Directory where the project should be created (default: current directory)")
 	force := pflag.Bool("force", false, "Overwrite existing files")
@@ -45,11 +111,108 @@ func Initialize() {
 
 	if *help || pflag.NArg() < 1 {
 		fmt.Println("Usage: bubbletea-init [flags] <project-name>")
+		fmt.Println("       bubbletea-init add [flags] <component>")
+		fmt.Println("       bubbletea-init new component [flags] <Name>")
+		fmt.Println("       bubbletea-init theme import [flags] <file>")
 		fmt.Println("\nFlags:")
 		pflag.PrintDefaults()
+		printTemplates()
 		Exit(0)
 	}
 
+	if *withBubbles {
+		if pflag.CommandLine.Changed("template") && *templateName != "bubbles" {
+			fmt.Printf("Error: --with-bubbles cannot be combined with --template %s\n", *templateName)
+			Exit(1)
+		}
+		*templateName = "bubbles"
+	}
+
+	if *noDeps {
+		if *templateName != "bubbles" {
+			fmt.Println("Error: --no-deps only applies together with --with-bubbles")
+			Exit(1)
+		}
+		*templateName = "bubbles-no-deps"
+	}
+
+	projTemplate, ok := projectTemplates[*templateName]
+	if !ok {
+		fmt.Printf("Error: unknown template '%s'. Available templates: %s\n", *templateName, strings.Join(templateOrder, ", "))
+		Exit(1)
+	}
+
+	switch {
+	case *layout != "flat" && *layout != "standard":
+		fmt.Printf("Error: unknown layout '%s'. Available layouts: %s\n", *layout, strings.Join(layouts, ", "))
+		Exit(1)
+	case *layout == "standard" && projTemplate.standardUI == nil:
+		fmt.Printf("Error: the %s template does not support --layout standard\n", *templateName)
+		Exit(1)
+	}
+
+	for _, name := range projTemplate.unsupportedFlags {
+		if pflag.CommandLine.Changed(name) {
+			fmt.Printf("Error: --%s does not apply to the %s template\n", name, *templateName)
+			Exit(1)
+		}
+	}
+
+	if *altScreen && *inline {
+		fmt.Println("Error: --alt-screen and --inline cannot be combined")
+		Exit(1)
+	}
+
+	if *mouse != "" && !slices.Contains(mouseModes, *mouse) {
+		fmt.Printf("Error: unknown mouse mode '%s'. Available modes: %s\n", *mouse, strings.Join(mouseModes, ", "))
+		Exit(1)
+	}
+
+	switch {
+	case *viewLayout != "" && !slices.Contains(viewLayouts, *viewLayout):
+		fmt.Printf("Error: unknown view layout '%s'. Available view layouts: %s\n", *viewLayout, strings.Join(viewLayouts, ", "))
+		Exit(1)
+	case *viewLayout != "" && *templateName != "default":
+		fmt.Printf("Error: --view-layout only applies to the default template, not %s\n", *templateName)
+		Exit(1)
+	}
+
+	if *withForm && *templateName != "multi-screen" {
+		fmt.Printf("Error: --with-form only applies to the multi-screen template, not %s (see the form template)\n", *templateName)
+		Exit(1)
+	}
+
+	if *withPalette && *templateName != "default" {
+		fmt.Printf("Error: --with-command-palette only applies to the default template, not %s\n", *templateName)
+		Exit(1)
+	}
+
+	if *withOverlays && !slices.Contains(overlayTemplates, *templateName) {
+		fmt.Printf("Error: --with-overlays only applies to the %s templates, not %s\n", strings.Join(overlayTemplates, " and "), *templateName)
+		Exit(1)
+	}
+
+	if *withEditor && !slices.Contains(editorTemplates, *templateName) {
+		fmt.Printf("Error: --with-editor only applies to the %s templates, not %s\n", strings.Join(editorTemplates, " and "), *templateName)
+		Exit(1)
+	}
+
+	for _, name := range fileBrowserFlags {
+		if pflag.CommandLine.Changed(name) && *templateName != "file-browser" {
+			fmt.Printf("Error: --%s only applies to the file-browser template, not %s\n", name, *templateName)
+			Exit(1)
+		}
+	}
+	if !slices.Contains(selectActions, *onSelect) {
+		fmt.Printf("Error: unknown --on-select action '%s'. Available actions: %s\n", *onSelect, strings.Join(selectActions, ", "))
+		Exit(1)
+	}
+
+	if !slices.Contains(themes, *themeName) {
+		fmt.Printf("Error: unknown theme '%s'. Available themes: %s\n", *themeName, strings.Join(themes, ", "))
+		Exit(1)
+	}
+
 	projectName := pflag.Arg(0)
 	var projectDir string
 
@@ -73,60 +236,125 @@ func Initialize() {
 		Exit(1)
 	}
 
-	data := templateData{
-		ProjectName: projectName,
+	modName := *modPath
+	if modName == "" {
+		modName = fmt.Sprintf("github.com/%s/%s", "yourusername", projectName)
 	}
 
-	// Choose template based on flags
-	templateContent := mainTemplate
-	if *withBubbles {
-		templateContent = bubblesTemplate
+	files := projTemplate.layoutFiles(*layout)
+	reqs := projTemplate.layoutRequires(*layout)
+	if *cli {
+		files = append(files, mainFiles(cliFiles, *layout)...)
+		reqs = append(reqs, pflagRequirement, termRequirement)
 	}
-
-	tmpl, err := template.New("main").Parse(templateContent)
-	if err != nil {
-		fmt.Println("Error parsing template:", err)
-		Exit(1)
+	if *debug {
+		files = append(files, mainFiles(debugFiles, *layout)...)
 	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		fmt.Println("Error executing template:", err)
-		Exit(1)
+	keys := projTemplate.keys
+	if *viewLayout != "" {
+		files = append(files, uiFiles(viewFiles, *layout)...)
+		keys = keys.with(upBinding, downBinding)
 	}
-
-	mainFile := filepath.Join(projectDir, "main.go")
-	if err := os.WriteFile(mainFile, buf.Bytes(), 0644); err != nil {
-		fmt.Println("Error writing main.go:", err)
-		Exit(1)
+	if *withForm {
+		files = append(files, uiFiles(formFiles, *layout)...)
+		files = append(files, uiFiles(formScreenFiles, *layout)...)
+		reqs = append(reqs, huhRequirement)
+	}
+	if *withPalette {
+		files = append(files, uiFiles(paletteFiles, *layout)...)
+		keys = keys.with(paletteBinding)
+		reqs = append(reqs, fuzzyRequirement, reflowRequirement)
+	}
+	if *withOverlays {
+		for _, f := range overlayFiles {
+			files = append(files, projectFile{path.Join(overlayDir(*layout), f.path), f.content})
+		}
+		reqs = append(reqs, reflowRequirement)
+	}
+	if *withEditor {
+		files = append(files, uiFiles(editorFiles, *layout)...)
+		keys = keys.withFull(editorBindings...)
+	}
+	if *reportFocus {
+		reqs = withVersion(reqs, focusTeaRequirement)
 	}
 
-	modName := *modPath
-	if modName == "" {
-		modName = fmt.Sprintf("github.com/%s/%s", "yourusername", projectName)
+	useAltScreen := (projTemplate.altScreen || *altScreen) && !*inline
+
+	data := templateData{
+		ProjectName:    projectName,
+		ModulePath:     modName,
+		Package:        "main",
+		Layout:         *layout,
+		EnvPrefix:      envPrefix(projectName),
+		AltScreen:      useAltScreen,
+		Mouse:          *mouse,
+		ReportFocus:    *reportFocus,
+		ProgramOptions: programOptions(useAltScreen, *mouse, *reportFocus),
+		Keys:           keys,
+		Theme:          *themeName,
+		ThemePath:      path.Join(modName, themeDir(*layout)),
+		Bubbles:        requires(reqs, bubblesModule),
+		CLI:            *cli,
+		Debug:          *debug,
+		ViewLayout:     *viewLayout,
+		Form:           *withForm,
+		Palette:        *withPalette,
+		Overlays:       *withOverlays,
+		OverlayPath:    path.Join(modName, overlayDir(*layout)),
+		Editor:         *withEditor,
+		Extensions:     fileExtensions(*extensions),
+		ShowHidden:     *showHidden,
+		OnSelect:       *onSelect,
+	}
+	if *layout == "standard" {
+		data.Package = "ui"
 	}
 
-	var goModContent string
-	if *withBubbles {
-		goModContent = fmt.Sprintf(`module %s
+	for _, file := range files {
+		filePath, err := renderString(file.path, data)
+		if err != nil {
+			fmt.Println("Error executing template:", err)
+			Exit(1)
+		}
 
-go 1.23
+		tmpl, err := template.New(filePath).Parse(file.content)
+		if err == nil {
+			_, err = tmpl.New("place").Parse(placeTemplate)
+		}
+		if err != nil {
+			fmt.Println("Error parsing template:", err)
+			Exit(1)
+		}
 
-require (
-	github.com/charmbracelet/bubbletea v0.25.0
-	github.com/charmbracelet/lipgloss v0.9.1
-)
-`, modName)
-	} else {
-		goModContent = fmt.Sprintf(`module %s
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			fmt.Println("Error executing template:", err)
+			Exit(1)
+		}
 
-go 1.23
+		content := buf.Bytes()
+		if isGoFile(filePath) {
+			formatted, err := format.Source(content)
+			if err != nil {
+				fmt.Printf("Error formatting %s: %v\n", filePath, err)
+				Exit(1)
+			}
+			content = formatted
+		}
 
-require github.com/charmbracelet/bubbletea v0.25.0
-`, modName)
+		target := filepath.Join(projectDir, filepath.FromSlash(filePath))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			fmt.Printf("Error creating directory for %s: %v\n", filePath, err)
+			Exit(1)
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			fmt.Printf("Error writing %s: %v\n", filePath, err)
+			Exit(1)
+		}
 	}
 
-	if err := os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte(goModContent), 0644); err != nil {
+	if err := os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte(goModContent(modName, reqs)), 0644); err != nil {
 		fmt.Println("Error writing go.mod:", err)
 		Exit(1)
 	}
@@ -136,5 +364,28 @@ require github.com/charmbracelet/bubbletea v0.25.0
 	fmt.Println("\nNext steps:")
 	fmt.Printf("  cd %s\n", projectName)
 	fmt.Println("  go mod tidy")
-	fmt.Println("  go run .")
+	if *layout == "standard" {
+		fmt.Println("  go test ./internal/ui -update  # record the golden files")
+	} else {
+		fmt.Println("  go test . -update  # record the golden files")
+	}
+	fmt.Println("  go test ./...")
+	if *layout == "standard" {
+		fmt.Printf("  go run ./cmd/%s\n", projectName)
+	} else {
+		fmt.Println("  go run .")
+	}
+}
+
+// renderString executes a short template such as a file path.
+func renderString(text string, data templateData) (string, error) {
+	tmpl, err := template.New(text).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
 }
@@ -0,0 +1,351 @@
+package init
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// colorScheme is a terminal color scheme read from a designer's file: a
+// background, a foreground and the 16 ANSI colors, all as "#rrggbb".
+type colorScheme struct {
+	name       string
+	format     string
+	background string
+	foreground string
+	ansi       [16]string
+
+	// surface is the background of bars and panels, when the format names
+	// one; base16 does (base01), terminal schemes don't.
+	surface string
+}
+
+// ANSI color indexes used when mapping a scheme to palette roles.
+const (
+	ansiBlack = iota
+	ansiRed
+	ansiGreen
+	ansiYellow
+	ansiBlue
+	ansiMagenta
+	ansiCyan
+	ansiWhite
+	ansiBrightBlack
+)
+
+// ansiNames are the color names Alacritty uses for the normal and bright
+// ANSI colors.
+var ansiNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// parseScheme reads a base16 YAML, Alacritty TOML or YAML, or iTerm
+// .itermcolors file. The format is chosen from the file extension and, for
+// YAML, from the keys in the file.
+func parseScheme(file string, data []byte) (colorScheme, error) {
+	var (
+		s   colorScheme
+		err error
+	)
+	switch ext := strings.ToLower(filepath.Ext(file)); ext {
+	case ".yaml", ".yml":
+		s, err = parseYAMLScheme(data)
+	case ".toml":
+		s, err = parseAlacritty(func(v any) error {
+			_, err := toml.Decode(string(data), v)
+			return err
+		})
+		s.format = "Alacritty TOML"
+	case ".itermcolors":
+		s, err = parseITerm(data)
+	default:
+		return colorScheme{}, fmt.Errorf("unsupported scheme file '%s'; expected .yaml, .yml, .toml or .itermcolors", filepath.Base(file))
+	}
+	if err != nil {
+		return colorScheme{}, fmt.Errorf("reading %s: %v", filepath.Base(file), err)
+	}
+
+	if s.name == "" {
+		s.name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	if err := s.validate(); err != nil {
+		return colorScheme{}, fmt.Errorf("reading %s: %v", filepath.Base(file), err)
+	}
+	return s, nil
+}
+
+// parseYAMLScheme tells base16 schemes, which have base00-base0F keys either
+// at the top level or under "palette", from Alacritty's "colors" section.
+func parseYAMLScheme(data []byte) (colorScheme, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return colorScheme{}, err
+	}
+
+	if _, ok := doc["colors"]; ok {
+		s, err := parseAlacritty(func(v any) error { return yaml.Unmarshal(data, v) })
+		s.format = "Alacritty YAML"
+		return s, err
+	}
+
+	base := doc
+	if palette, ok := doc["palette"].(map[string]any); ok {
+		base = palette
+	}
+	if _, ok := base["base00"]; !ok {
+		return colorScheme{}, fmt.Errorf("neither a base16 scheme (no base00-base0F colors) nor an Alacritty config (no colors section)")
+	}
+	return parseBase16(doc, base)
+}
+
+// parseBase16 maps the 16 base16 colors onto the terminal colors the way
+// base16 shell templates do.
+func parseBase16(doc, base map[string]any) (colorScheme, error) {
+	var colors [16]string
+	for i := range colors {
+		key := fmt.Sprintf("base%02X", i)
+		raw, ok := base[key].(string)
+		if !ok {
+			return colorScheme{}, fmt.Errorf("missing %s", key)
+		}
+		c, err := normalizeHex(raw)
+		if err != nil {
+			return colorScheme{}, fmt.Errorf("%s: %v", key, err)
+		}
+		colors[i] = c
+	}
+
+	s := colorScheme{
+		format:     "base16 YAML",
+		background: colors[0x0],
+		foreground: colors[0x5],
+		surface:    colors[0x1],
+	}
+	for _, key := range []string{"name", "scheme"} {
+		if name, ok := doc[key].(string); ok {
+			s.name = name
+			break
+		}
+	}
+
+	normal := [8]int{0x0, 0x8, 0xB, 0xA, 0xD, 0xE, 0xC, 0x5}
+	bright := [8]int{0x3, 0x8, 0xB, 0xA, 0xD, 0xE, 0xC, 0x7}
+	for i := range normal {
+		s.ansi[i] = colors[normal[i]]
+		s.ansi[i+8] = colors[bright[i]]
+	}
+	return s, nil
+}
+
+// alacrittyConfig is the part of an Alacritty config holding its colors. The
+// TOML and YAML formats share the same structure.
+type alacrittyConfig struct {
+	Colors struct {
+		Primary struct {
+			Background string `toml:"background" yaml:"background"`
+			Foreground string `toml:"foreground" yaml:"foreground"`
+		} `toml:"primary" yaml:"primary"`
+		Normal map[string]string `toml:"normal" yaml:"normal"`
+		Bright map[string]string `toml:"bright" yaml:"bright"`
+	} `toml:"colors" yaml:"colors"`
+}
+
+func parseAlacritty(decode func(any) error) (colorScheme, error) {
+	var cfg alacrittyConfig
+	if err := decode(&cfg); err != nil {
+		return colorScheme{}, err
+	}
+
+	var s colorScheme
+	var err error
+	if s.background, err = normalizeHex(cfg.Colors.Primary.Background); err != nil {
+		return colorScheme{}, fmt.Errorf("colors.primary.background: %v", err)
+	}
+	if s.foreground, err = normalizeHex(cfg.Colors.Primary.Foreground); err != nil {
+		return colorScheme{}, fmt.Errorf("colors.primary.foreground: %v", err)
+	}
+	for i, name := range ansiNames {
+		if s.ansi[i], err = normalizeHex(cfg.Colors.Normal[name]); err != nil {
+			return colorScheme{}, fmt.Errorf("colors.normal.%s: %v", name, err)
+		}
+		if s.ansi[i+8], err = normalizeHex(cfg.Colors.Bright[name]); err != nil {
+			return colorScheme{}, fmt.Errorf("colors.bright.%s: %v", name, err)
+		}
+	}
+	return s, nil
+}
+
+// parseITerm reads the XML property list iTerm2 exports. Each color is a
+// dictionary of red, green and blue components between 0 and 1.
+func parseITerm(data []byte) (colorScheme, error) {
+	colors, err := plistColors(data)
+	if err != nil {
+		return colorScheme{}, err
+	}
+
+	s := colorScheme{format: "iTerm"}
+	lookup := func(key string) (string, error) {
+		c, ok := colors[key]
+		if !ok {
+			return "", fmt.Errorf("missing %s", key)
+		}
+		return c, nil
+	}
+	if s.background, err = lookup("Background Color"); err != nil {
+		return colorScheme{}, err
+	}
+	if s.foreground, err = lookup("Foreground Color"); err != nil {
+		return colorScheme{}, err
+	}
+	for i := range s.ansi {
+		if s.ansi[i], err = lookup(fmt.Sprintf("Ansi %d Color", i)); err != nil {
+			return colorScheme{}, err
+		}
+	}
+	return s, nil
+}
+
+// plistColors returns the colors of an .itermcolors file by name.
+func plistColors(data []byte) (map[string]string, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	colors := map[string]string{}
+
+	// The file is a top-level <dict> alternating <key> names and <dict>
+	// colors, which in turn alternate component names and <real> values.
+	var (
+		depth     int
+		name      string
+		component string
+		text      strings.Builder
+		rgb       map[string]float64
+	)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("invalid property list: %v", err)
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			text.Reset()
+			if tok.Name.Local == "dict" {
+				depth++
+				if depth == 2 {
+					rgb = map[string]float64{}
+				}
+			}
+		case xml.CharData:
+			text.Write(tok)
+		case xml.EndElement:
+			value := strings.TrimSpace(text.String())
+			switch {
+			case tok.Name.Local == "key" && depth == 1:
+				name = value
+			case tok.Name.Local == "key" && depth == 2:
+				component = value
+			case tok.Name.Local == "real" && depth == 2:
+				f, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%s: invalid %s '%s'", name, component, value)
+				}
+				rgb[component] = f
+			case tok.Name.Local == "dict":
+				if depth == 2 {
+					colors[name] = fmt.Sprintf("#%02X%02X%02X",
+						colorByte(rgb["Red Component"]),
+						colorByte(rgb["Green Component"]),
+						colorByte(rgb["Blue Component"]))
+				}
+				depth--
+			}
+		}
+	}
+
+	if len(colors) == 0 {
+		return nil, fmt.Errorf("no colors found; is this an iTerm color scheme?")
+	}
+	return colors, nil
+}
+
+func colorByte(f float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, f)) * 255))
+}
+
+// normalizeHex accepts "#rrggbb", "rrggbb" and Alacritty's "0xrrggbb" and
+// returns "#RRGGBB".
+func normalizeHex(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	hex := strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(s, "#"), "0x"), "0X")
+	if len(hex) != 6 {
+		if s == "" {
+			return "", fmt.Errorf("missing color")
+		}
+		return "", fmt.Errorf("invalid color '%s'", s)
+	}
+	if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+		return "", fmt.Errorf("invalid color '%s'", s)
+	}
+	return "#" + strings.ToUpper(hex), nil
+}
+
+func (s colorScheme) validate() error {
+	for _, c := range append([]string{s.background, s.foreground}, s.ansi[:]...) {
+		if c == "" {
+			return fmt.Errorf("the scheme does not define all 16 terminal colors")
+		}
+	}
+	return nil
+}
+
+// dark reports whether the scheme has a dark background.
+func (s colorScheme) dark() bool {
+	return luminance(s.background) < 0.5
+}
+
+// luminance returns the relative luminance of a "#RRGGBB" color between 0
+// and 1.
+func luminance(hex string) float64 {
+	v, _ := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	r, g, b := float64(v>>16&0xFF), float64(v>>8&0xFF), float64(v&0xFF)
+	return (0.2126*r + 0.7152*g + 0.0722*b) / 255
+}
+
+// schemePalette maps a scheme onto the semantic colors of the generated theme
+// package.
+type schemePalette struct {
+	Primary, OnPrimary, Text, Muted, Surface, Error, Success, Warning string
+}
+
+func (s colorScheme) palette() schemePalette {
+	// Terminal schemes often make black the background color, which would
+	// hide bars drawn on it.
+	surface := s.surface
+	if surface == "" {
+		surface = s.ansi[ansiBlack]
+		if !s.dark() {
+			surface = s.ansi[ansiWhite]
+		}
+		if surface == s.background {
+			surface = s.ansi[ansiBrightBlack]
+		}
+	}
+	return schemePalette{
+		Primary:   s.ansi[ansiBlue],
+		OnPrimary: s.background,
+		Text:      s.foreground,
+		Muted:     s.ansi[ansiBrightBlack],
+		Surface:   surface,
+		Error:     s.ansi[ansiRed],
+		Success:   s.ansi[ansiGreen],
+		Warning:   s.ansi[ansiYellow],
+	}
+}
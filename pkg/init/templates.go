@@ -0,0 +1,784 @@
+package init
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// requirement is a module a generated project depends on.
+type requirement struct {
+	module  string
+	version string
+}
+
+var (
+	teaRequirement      = requirement{teaImportPath, "v0.25.0"}
+	lipglossRequirement = requirement{lipglossModule, lipglossVersion}
+	bubblesRequirement  = requirement{bubblesModule, bubblesVersion}
+
+	// termenvRequirement lets generated tests force lipgloss's color profile.
+	termenvRequirement = requirement{"github.com/muesli/termenv", "v0.15.2"}
+
+	// Focus and blur messages arrived in Bubble Tea v1.1.
+	focusTeaRequirement = requirement{teaImportPath, "v1.1.0"}
+
+	// The --cli entrypoint parses flags with pflag and detects terminals
+	// with x/term.
+	pflagRequirement = requirement{"github.com/spf13/pflag", "v1.0.5"}
+	termRequirement  = requirement{"golang.org/x/term", "v0.6.0"}
+
+	// The wish template serves the program over SSH. Its tests connect with
+	// the x/crypto SSH client.
+	wishRequirement      = requirement{"github.com/charmbracelet/wish", "v1.3.1"}
+	sshRequirement       = requirement{"github.com/charmbracelet/ssh", "v0.0.0-20240202115812-f4ab1009799a"}
+	logRequirement       = requirement{"github.com/charmbracelet/log", "v0.3.1"}
+	cryptoSSHRequirement = requirement{"golang.org/x/crypto", "v0.18.0"}
+
+	// The form template and --with-form build forms with huh.
+	huhRequirement = requirement{"github.com/charmbracelet/huh", "v0.3.0"}
+
+	// The markdown-viewer template renders Markdown with glamour.
+	glamourRequirement = requirement{"github.com/charmbracelet/glamour", "v0.7.0"}
+
+	// The table template filters rows with the fuzzy matcher bubbles/list
+	// uses.
+	fuzzyRequirement = requirement{"github.com/sahilm/fuzzy", "v0.1.1"}
+
+	// The tail template wraps and cuts off lines that may hold escape
+	// sequences with reflow, which lipgloss already depends on.
+	reflowRequirement = requirement{"github.com/muesli/reflow", "v0.3.0"}
+)
+
+// projectFile is a file rendered into a new project.
+type projectFile struct {
+	path    string // slash-separated, relative to the project root
+	content string
+}
+
+// projectTemplate is a project skeleton selectable with --template.
+type projectTemplate struct {
+	description string
+	files       []projectFile
+	requires    []requirement
+
+	// standardUI holds the files placed in internal/ui for --layout
+	// standard. Templates without them only support the flat layout.
+	standardUI []projectFile
+
+	// altScreen makes the program run in the terminal's alternate screen.
+	altScreen bool
+
+	// keys describes the key map rendered into keys.go, if the template has
+	// one.
+	keys *keyMapSpec
+
+	// unsupportedFlags lists generator flags that don't apply to the
+	// template.
+	unsupportedFlags []string
+}
+
+// keyMapSpec describes the keyMap generated by templates/keys.go.tmpl.
+type keyMapSpec struct {
+	Bindings []binding
+	Short    []string   // fields shown by ShortHelp
+	Full     [][]string // columns shown by FullHelp
+}
+
+// Bindings added to a key map whose model moves a cursor.
+var (
+	upBinding   = binding{"up", "Up", []string{"up", "k"}, "↑/k", "up"}
+	downBinding = binding{"down", "Down", []string{"down", "j"}, "↓/j", "down"}
+)
+
+// paletteBinding opens the command palette added by --with-command-palette.
+var paletteBinding = binding{"palette", "Palette", []string{"ctrl+p"}, "ctrl+p", "commands"}
+
+// editorBindings open the selected todo or file in $EDITOR and $PAGER with
+// --with-editor.
+var editorBindings = []binding{
+	{"editor", "Editor", []string{"E"}, "E", "open in editor"},
+	{"pager", "Pager", []string{"v"}, "v", "open in pager"},
+}
+
+// overlayTemplates are the templates --with-overlays applies to.
+var overlayTemplates = []string{"default", "multi-screen"}
+
+// editorTemplates are the templates --with-editor applies to.
+var editorTemplates = []string{"todo", "file-browser"}
+
+// with returns a copy of k with bs added, shown first in the help.
+func (k *keyMapSpec) with(bs ...binding) *keyMapSpec {
+	if k == nil {
+		return nil
+	}
+	out := &keyMapSpec{Bindings: append(append([]binding{}, bs...), k.Bindings...)}
+	var fields []string
+	for _, b := range bs {
+		fields = append(fields, b.Field)
+	}
+	out.Short = append(append([]string{}, fields...), k.Short...)
+	out.Full = append([][]string{fields}, k.Full...)
+	return out
+}
+
+// withFull returns a copy of k with bs added to the full help only, for
+// bindings the short help has no room for.
+func (k *keyMapSpec) withFull(bs ...binding) *keyMapSpec {
+	if k == nil {
+		return nil
+	}
+	out := &keyMapSpec{Bindings: append(append([]binding{}, k.Bindings...), bs...), Short: k.Short}
+	var fields []string
+	for _, b := range bs {
+		fields = append(fields, b.Field)
+	}
+	out.Full = append(append([][]string{}, k.Full...), fields)
+	return out
+}
+
+// binding is one key.Binding of a keyMapSpec.
+type binding struct {
+	Name  string // key in the user's key map file
+	Field string
+	Keys  []string
+	Help  string // key as shown in the help view
+	Desc  string
+}
+
+//go:embed templates/main_test.go.tmpl
+var mainTestTemplate string
+
+//go:embed templates/main_with_bubbles_test.go.tmpl
+var bubblesTestTemplate string
+
+//go:embed templates/main_no_deps_test.go.tmpl
+var noDepsTestTemplate string
+
+//go:embed templates/keys.go.tmpl
+var keysTemplate string
+
+//go:embed templates/harness_test.go.tmpl
+var harnessTemplate string
+
+// placeTemplate draws one view over another. The command palette and the
+// overlay package both include it as {{template "place"}}, after importing
+// strings and reflow's ansi and truncate.
+//
+//go:embed templates/place.tmpl
+var placeTemplate string
+
+//go:embed templates/theme
+var themeFS embed.FS
+
+//go:embed templates/cli
+var cliFS embed.FS
+
+//go:embed templates/debug
+var debugFS embed.FS
+
+//go:embed templates/view-layout
+var viewLayoutFS embed.FS
+
+//go:embed templates/multi-screen
+var multiScreenFiles embed.FS
+
+//go:embed templates/wish
+var wishFS embed.FS
+
+//go:embed templates/async
+var asyncFS embed.FS
+
+//go:embed templates/form
+var formFS embed.FS
+
+//go:embed templates/form-app
+var formAppFS embed.FS
+
+//go:embed templates/form-screen
+var formScreenFS embed.FS
+
+//go:embed templates/markdown-viewer
+var markdownViewerFS embed.FS
+
+//go:embed templates/table
+var tableFS embed.FS
+
+//go:embed templates/todo
+var todoFS embed.FS
+
+//go:embed templates/tail
+var tailFS embed.FS
+
+//go:embed templates/file-browser
+var fileBrowserFS embed.FS
+
+//go:embed templates/dashboard
+var dashboardFS embed.FS
+
+//go:embed templates/command-palette
+var commandPaletteFS embed.FS
+
+//go:embed templates/overlay
+var overlayFS embed.FS
+
+//go:embed templates/editor
+var editorFS embed.FS
+
+//go:embed templates/standard/main.go.tmpl
+var standardMainTemplate string
+
+//go:embed templates/standard/config.go.tmpl
+var standardConfigTemplate string
+
+//go:embed templates/standard/app.go.tmpl
+var standardAppTemplate string
+
+//go:embed templates/standard/ui
+var standardUIFiles embed.FS
+
+var (
+	multiScreen = embeddedFiles(multiScreenFiles, "templates/multi-screen")
+	themeFiles  = embeddedFiles(themeFS, "templates/theme")
+	cliFiles    = embeddedFiles(cliFS, "templates/cli")
+	debugFiles  = embeddedFiles(debugFS, "templates/debug")
+	viewFiles   = embeddedFiles(viewLayoutFS, "templates/view-layout")
+
+	// formFiles hold the form itself, shared by the form template and the
+	// screen added to the multi-screen template by --with-form.
+	formFiles       = embeddedFiles(formFS, "templates/form")
+	formScreenFiles = embeddedFiles(formScreenFS, "templates/form-screen")
+
+	// paletteFiles hold the command palette and action registry added to
+	// the default template by --with-command-palette.
+	paletteFiles = embeddedFiles(commandPaletteFS, "templates/command-palette")
+
+	// overlayFiles hold the overlay package of dialogs and toasts added to
+	// the default and multi-screen templates by --with-overlays.
+	overlayFiles = embeddedFiles(overlayFS, "templates/overlay")
+
+	// editorFiles hold the helpers that run $EDITOR and $PAGER, added to the
+	// todo and file-browser templates by --with-editor.
+	editorFiles = embeddedFiles(editorFS, "templates/editor")
+)
+
+// templateOrder is the order in which templates are listed in the help output.
+var templateOrder = []string{"default", "bubbles", "bubbles-no-deps", "multi-screen", "form", "markdown-viewer", "table", "todo", "tail", "file-browser", "dashboard", "async", "wish"}
+
+var projectTemplates = map[string]projectTemplate{
+	"default": {
+		description: "Minimal single-model program",
+		files: []projectFile{
+			{"main.go", mainTemplate},
+			{"keys.go", keysTemplate},
+			{"main_test.go", mainTestTemplate},
+		},
+		requires:   []requirement{bubblesRequirement, teaRequirement},
+		standardUI: embeddedFiles(standardUIFiles, "templates/standard/ui"),
+		keys: &keyMapSpec{
+			Bindings: []binding{
+				{"help", "Help", []string{"?"}, "?", "toggle help"},
+				{"quit", "Quit", []string{"q", "ctrl+c"}, "q", "quit"},
+			},
+			Short: []string{"Help", "Quit"},
+			Full:  [][]string{{"Help", "Quit"}},
+		},
+	},
+	"bubbles": {
+		description: "Spinner and text input from charmbracelet/bubbles (same as --with-bubbles)",
+		files: []projectFile{
+			{"main.go", bubblesTemplate},
+			{"keys.go", keysTemplate},
+			{"main_test.go", bubblesTestTemplate},
+		},
+		requires: []requirement{bubblesRequirement, teaRequirement, lipglossRequirement},
+		keys: &keyMapSpec{
+			Bindings: []binding{
+				{"submit", "Submit", []string{"enter"}, "enter", "submit"},
+				{"quit", "Quit", []string{"esc", "ctrl+c"}, "esc", "quit"},
+			},
+			Short: []string{"Submit", "Quit"},
+			Full:  [][]string{{"Submit"}, {"Quit"}},
+		},
+	},
+	"bubbles-no-deps": {
+		description: "Hand-rolled spinner and text input for learning (same as --with-bubbles --no-deps)",
+		files:       []projectFile{{"main.go", noDepsTemplate}, {"main_test.go", noDepsTestTemplate}},
+		requires:    []requirement{teaRequirement, lipglossRequirement},
+	},
+	"multi-screen": {
+		description: "Several screens on a navigation stack with a shared status bar",
+		files:       multiScreen,
+		requires:    []requirement{bubblesRequirement, teaRequirement, lipglossRequirement},
+		standardUI:  without(multiScreen, "main.go"),
+		altScreen:   true,
+	},
+	"form": {
+		description: "Form with inputs, a select and a confirm built with charmbracelet/huh, followed by a summary",
+		files: append(append(embeddedFiles(formAppFS, "templates/form-app"), formFiles...),
+			projectFile{"keys.go", keysTemplate}),
+		requires: []requirement{bubblesRequirement, teaRequirement, lipglossRequirement, huhRequirement},
+		keys: &keyMapSpec{
+			Bindings: []binding{
+				{"edit", "Edit", []string{"e"}, "e", "edit answers"},
+				{"quit", "Quit", []string{"q", "ctrl+c"}, "q", "quit"},
+			},
+			Short: []string{"Edit", "Quit"},
+			Full:  [][]string{{"Edit", "Quit"}},
+		},
+		// The form has no plain-text view and doesn't handle mouse or focus
+		// messages.
+		unsupportedFlags: []string{"cli", "mouse", "report-focus"},
+	},
+	"markdown-viewer": {
+		description: "Markdown document rendered with charmbracelet/glamour in a scrolling viewport, with search",
+		files:       append(embeddedFiles(markdownViewerFS, "templates/markdown-viewer"), projectFile{"keys.go", keysTemplate}),
+		requires:    []requirement{bubblesRequirement, teaRequirement, lipglossRequirement, glamourRequirement},
+		altScreen:   true,
+		keys: &keyMapSpec{
+			Bindings: []binding{
+				upBinding,
+				downBinding,
+				{"page_up", "PageUp", []string{"pgup", "b"}, "b", "page up"},
+				{"page_down", "PageDown", []string{"pgdown", "f", " "}, "f", "page down"},
+				{"search", "Search", []string{"/"}, "/", "search"},
+				{"next", "Next", []string{"n"}, "n", "next match"},
+				{"prev", "Prev", []string{"N"}, "N", "previous match"},
+				{"style", "Style", []string{"s"}, "s", "switch style"},
+				{"help", "Help", []string{"?"}, "?", "more"},
+				{"quit", "Quit", []string{"q", "ctrl+c"}, "q", "quit"},
+			},
+			Short: []string{"Search", "Style", "Help", "Quit"},
+			Full: [][]string{
+				{"Up", "Down", "PageUp", "PageDown"},
+				{"Search", "Next", "Prev"},
+				{"Style", "Help", "Quit"},
+			},
+		},
+		// The command line names the document, and the viewer doesn't
+		// handle focus messages.
+		unsupportedFlags: []string{"cli", "report-focus"},
+	},
+	"table": {
+		description: "CSV or JSON data in a bubbles/table with sorting, fuzzy filtering, a detail pane and CSV export",
+		files:       append(embeddedFiles(tableFS, "templates/table"), projectFile{"keys.go", keysTemplate}),
+		requires:    []requirement{bubblesRequirement, teaRequirement, lipglossRequirement, fuzzyRequirement},
+		altScreen:   true,
+		keys: &keyMapSpec{
+			Bindings: []binding{
+				upBinding,
+				downBinding,
+				{"left", "Left", []string{"left", "h"}, "←/h", "previous column"},
+				{"right", "Right", []string{"right", "l"}, "→/l", "next column"},
+				{"sort", "Sort", []string{"s"}, "s", "sort"},
+				{"filter", "Filter", []string{"/"}, "/", "filter"},
+				{"detail", "Detail", []string{"enter"}, "enter", "details"},
+				{"export", "Export", []string{"x"}, "x", "export"},
+				{"help", "Help", []string{"?"}, "?", "more"},
+				{"quit", "Quit", []string{"q", "ctrl+c"}, "q", "quit"},
+			},
+			Short: []string{"Sort", "Filter", "Detail", "Help", "Quit"},
+			Full: [][]string{
+				{"Up", "Down", "Left", "Right"},
+				{"Sort", "Filter", "Detail", "Export"},
+				{"Help", "Quit"},
+			},
+		},
+		// The command line names the data file, and the model doesn't
+		// handle mouse or focus messages.
+		unsupportedFlags: []string{"cli", "mouse", "report-focus"},
+	},
+	"todo": {
+		description: "Todo list in a bubbles/list with add, edit and delete dialogs, filtering and JSON persistence",
+		files:       append(embeddedFiles(todoFS, "templates/todo"), projectFile{"keys.go", keysTemplate}),
+		requires:    []requirement{bubblesRequirement, teaRequirement, lipglossRequirement},
+		altScreen:   true,
+		keys: &keyMapSpec{
+			Bindings: []binding{
+				upBinding,
+				downBinding,
+				{"add", "Add", []string{"a"}, "a", "add"},
+				{"edit", "Edit", []string{"e"}, "e", "edit"},
+				{"toggle", "Toggle", []string{" "}, "space", "done"},
+				{"delete", "Delete", []string{"x", "delete"}, "x", "delete"},
+				{"quit", "Quit", []string{"q", "ctrl+c"}, "q", "quit"},
+			},
+			// The list shows its own bindings for moving and filtering
+			// next to these.
+			Short: []string{"Add", "Toggle", "Quit"},
+			Full:  [][]string{{"Add", "Edit", "Toggle", "Delete", "Quit"}},
+		},
+		// The todos come from the data file rather than the command line,
+		// and the model doesn't handle mouse or focus messages.
+		unsupportedFlags: []string{"cli", "mouse", "report-focus"},
+	},
+	"tail": {
+		description: "Follows a file or a command's output in a viewport, with pause, filtering and wrapping",
+		files:       append(embeddedFiles(tailFS, "templates/tail"), projectFile{"keys.go", keysTemplate}),
+		requires:    []requirement{bubblesRequirement, teaRequirement, lipglossRequirement, reflowRequirement},
+		altScreen:   true,
+		keys: &keyMapSpec{
+			Bindings: []binding{
+				upBinding,
+				downBinding,
+				{"page_up", "PageUp", []string{"pgup", "b"}, "b", "page up"},
+				{"page_down", "PageDown", []string{"pgdown", " "}, "space", "page down"},
+				{"follow", "Follow", []string{"f", "end"}, "f", "follow"},
+				{"pause", "Pause", []string{"p"}, "p", "pause"},
+				{"wrap", "Wrap", []string{"w"}, "w", "wrap"},
+				{"filter", "Filter", []string{"/"}, "/", "filter"},
+				{"help", "Help", []string{"?"}, "?", "more"},
+				{"quit", "Quit", []string{"q", "ctrl+c"}, "q", "quit"},
+			},
+			Short: []string{"Follow", "Pause", "Filter", "Help", "Quit"},
+			Full: [][]string{
+				{"Up", "Down", "PageUp", "PageDown"},
+				{"Follow", "Pause", "Wrap", "Filter"},
+				{"Help", "Quit"},
+			},
+		},
+		// The command line names the file or command, and the model
+		// doesn't handle mouse or focus messages.
+		unsupportedFlags: []string{"cli", "mouse", "report-focus"},
+	},
+	"file-browser": {
+		description: "bubbles/filepicker with a preview pane, extension filtering, a hidden-file toggle and a selection hook",
+		files:       append(embeddedFiles(fileBrowserFS, "templates/file-browser"), projectFile{"keys.go", keysTemplate}),
+		requires:    []requirement{bubblesRequirement, teaRequirement, lipglossRequirement},
+		altScreen:   true,
+		keys: &keyMapSpec{
+			Bindings: []binding{
+				upBinding,
+				downBinding,
+				{"page_up", "PageUp", []string{"pgup", "K"}, "pgup", "page up"},
+				{"page_down", "PageDown", []string{"pgdown", "J"}, "pgdown", "page down"},
+				{"back", "Back", []string{"left", "h", "backspace", "esc"}, "←/h", "parent"},
+				{"open", "Open", []string{"right", "l", "enter"}, "→/l", "open"},
+				{"select", "Select", []string{"enter"}, "enter", "select"},
+				{"hidden", "Hidden", []string{"."}, ".", "hidden files"},
+				{"help", "Help", []string{"?"}, "?", "more"},
+				{"quit", "Quit", []string{"q", "ctrl+c"}, "q", "quit"},
+			},
+			Short: []string{"Back", "Open", "Select", "Help", "Quit"},
+			Full: [][]string{
+				{"Up", "Down", "PageUp", "PageDown"},
+				{"Back", "Open", "Select", "Hidden"},
+				{"Help", "Quit"},
+			},
+		},
+		// The command line names the directory, and the model doesn't
+		// handle mouse or focus messages.
+		unsupportedFlags: []string{"cli", "mouse", "report-focus"},
+	},
+	"dashboard": {
+		description: "Grid of panels that refresh on their own intervals, with sparklines, gauges and stale-data highlighting",
+		files:       append(embeddedFiles(dashboardFS, "templates/dashboard"), projectFile{"keys.go", keysTemplate}),
+		requires:    []requirement{bubblesRequirement, teaRequirement, lipglossRequirement},
+		altScreen:   true,
+		keys: &keyMapSpec{
+			Bindings: []binding{
+				{"next", "Next", []string{"tab"}, "tab", "next panel"},
+				{"prev", "Prev", []string{"shift+tab"}, "shift+tab", "previous panel"},
+				{"refresh", "Refresh", []string{"r"}, "r", "refresh"},
+				{"help", "Help", []string{"?"}, "?", "more"},
+				{"quit", "Quit", []string{"q", "ctrl+c"}, "q", "quit"},
+			},
+			Short: []string{"Next", "Refresh", "Help", "Quit"},
+			Full: [][]string{
+				{"Next", "Prev"},
+				{"Refresh", "Help", "Quit"},
+			},
+		},
+		// The panels fetch their own data, and the model doesn't handle
+		// mouse or focus messages.
+		unsupportedFlags: []string{"cli", "mouse", "report-focus"},
+	},
+	"async": {
+		description: "HTTP download with context cancellation, retries with backoff and progress sent with Program.Send",
+		files:       append(embeddedFiles(asyncFS, "templates/async"), projectFile{"keys.go", keysTemplate}),
+		requires:    []requirement{bubblesRequirement, teaRequirement, lipglossRequirement},
+		keys: &keyMapSpec{
+			Bindings: []binding{
+				{"reload", "Reload", []string{"r"}, "r", "download again"},
+				{"quit", "Quit", []string{"q", "ctrl+c"}, "q", "quit"},
+			},
+			Short: []string{"Reload", "Quit"},
+			Full:  [][]string{{"Reload", "Quit"}},
+		},
+		// main hands the program's Send method to the download, so it
+		// creates the program itself.
+		unsupportedFlags: []string{"cli", "mouse", "report-focus"},
+	},
+	"wish": {
+		description: "SSH server that runs a program in every session with charmbracelet/wish",
+		files:       append(embeddedFiles(wishFS, "templates/wish"), projectFile{"keys.go", keysTemplate}),
+		requires: []requirement{
+			bubblesRequirement, teaRequirement, lipglossRequirement,
+			wishRequirement, sshRequirement, logRequirement, cryptoSSHRequirement,
+		},
+		altScreen: true,
+		keys: &keyMapSpec{
+			Bindings: []binding{
+				{"help", "Help", []string{"?"}, "?", "toggle help"},
+				{"quit", "Quit", []string{"q", "ctrl+c"}, "q", "quit"},
+			},
+			Short: []string{"Help", "Quit"},
+			Full:  [][]string{{"Help", "Quit"}},
+		},
+		// The server has no command line or terminal of its own, and its
+		// model doesn't handle mouse or focus messages.
+		unsupportedFlags: []string{"cli", "debug", "mouse", "report-focus"},
+	},
+}
+
+// layouts lists the values accepted by --layout.
+var layouts = []string{"flat", "standard"}
+
+// viewLayouts lists the values accepted by --view-layout.
+var viewLayouts = []string{"stacked", "split"}
+
+// mouseModes lists the values accepted by --mouse.
+var mouseModes = []string{"cell", "all"}
+
+// selectActions lists the values accepted by --on-select.
+var selectActions = []string{"print", "status"}
+
+// fileBrowserFlags only apply to the file-browser template.
+var fileBrowserFlags = []string{"extensions", "show-hidden", "on-select"}
+
+// fileExtensions cleans up the values of --extensions: "go" and " .go" both
+// become ".go".
+func fileExtensions(values []string) []string {
+	var exts []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if !strings.HasPrefix(v, ".") {
+			v = "." + v
+		}
+		exts = append(exts, v)
+	}
+	return exts
+}
+
+// programOptions returns the tea.ProgramOptions main passes to
+// tea.NewProgram.
+func programOptions(altScreen bool, mouse string, reportFocus bool) []string {
+	var opts []string
+	if altScreen {
+		opts = append(opts, "tea.WithAltScreen()")
+	}
+	switch mouse {
+	case "cell":
+		opts = append(opts, "tea.WithMouseCellMotion()")
+	case "all":
+		opts = append(opts, "tea.WithMouseAllMotion()")
+	}
+	if reportFocus {
+		opts = append(opts, "tea.WithReportFocus()")
+	}
+	return opts
+}
+
+// themes lists the palettes accepted by --theme; the first is the default.
+var themes = []string{"charm", "dracula", "nord", "catppuccin", "mono"}
+
+// themeDir returns the directory of the theme package in the given layout.
+func themeDir(layout string) string {
+	if layout == "standard" {
+		return "internal/theme"
+	}
+	return "theme"
+}
+
+// overlayDir returns the directory of the overlay package in the given
+// layout.
+func overlayDir(layout string) string {
+	if layout == "standard" {
+		return "internal/overlay"
+	}
+	return "overlay"
+}
+
+// layoutFiles returns the files to render for the given layout. The standard
+// layout puts the template's UI in internal/ui next to shared cmd, config and
+// app packages. Either way the test harness goes next to the UI's tests.
+func (t projectTemplate) layoutFiles(layout string) []projectFile {
+	var files []projectFile
+	if layout != "standard" {
+		files = append(files, t.files...)
+		files = append(files, projectFile{"harness_test.go", harnessTemplate})
+	} else {
+		files = append(files,
+			projectFile{"cmd/{{.ProjectName}}/main.go", standardMainTemplate},
+			projectFile{"internal/config/config.go", standardConfigTemplate},
+			projectFile{"internal/app/app.go", standardAppTemplate},
+		)
+		for _, f := range t.standardUI {
+			files = append(files, projectFile{path.Join("internal/ui", f.path), f.content})
+		}
+		files = append(files, projectFile{"internal/ui/harness_test.go", harnessTemplate})
+	}
+
+	for _, f := range themeFiles {
+		files = append(files, projectFile{path.Join(themeDir(layout), f.path), f.content})
+	}
+	return files
+}
+
+// mainFiles places files, such as those added by --cli and --debug, next to
+// main.go, which calls into them.
+func mainFiles(files []projectFile, layout string) []projectFile {
+	dir := "."
+	if layout == "standard" {
+		dir = "cmd/{{.ProjectName}}"
+	}
+	var out []projectFile
+	for _, f := range files {
+		out = append(out, projectFile{path.Join(dir, f.path), f.content})
+	}
+	return out
+}
+
+// uiFiles places files, such as those added by --view-layout and
+// --with-form, next to the model, in the UI package.
+func uiFiles(files []projectFile, layout string) []projectFile {
+	dir := "."
+	if layout == "standard" {
+		dir = "internal/ui"
+	}
+	var out []projectFile
+	for _, f := range files {
+		out = append(out, projectFile{path.Join(dir, f.path), f.content})
+	}
+	return out
+}
+
+// layoutRequires returns the modules a project in the given layout needs.
+func (t projectTemplate) layoutRequires(layout string) []requirement {
+	reqs := t.requires
+	if layout == "standard" {
+		reqs = append([]requirement{bubblesRequirement}, reqs...)
+	}
+	// The theme package and generated tests depend on lipgloss and termenv.
+	return append(reqs, lipglossRequirement, termenvRequirement)
+}
+
+// withVersion returns reqs with the version of r's module replaced by r's.
+func withVersion(reqs []requirement, r requirement) []requirement {
+	out := make([]requirement, len(reqs))
+	for i, req := range reqs {
+		if req.module == r.module {
+			req = r
+		}
+		out[i] = req
+	}
+	return out
+}
+
+// requires reports whether reqs include module.
+func requires(reqs []requirement, module string) bool {
+	for _, r := range reqs {
+		if r.module == module {
+			return true
+		}
+	}
+	return false
+}
+
+// without returns files minus the one at path p.
+func without(files []projectFile, p string) []projectFile {
+	var out []projectFile
+	for _, f := range files {
+		if f.path != p {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// embeddedFiles returns the .tmpl files below root, named by their path
+// relative to root without the .tmpl suffix.
+func embeddedFiles(fsys embed.FS, root string) []projectFile {
+	var files []projectFile
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(p, ".tmpl") {
+			return err
+		}
+		content, err := fsys.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimPrefix(p, root+"/")
+		files = append(files, projectFile{strings.TrimSuffix(rel, ".tmpl"), string(content)})
+		return nil
+	})
+	if err != nil {
+		panic(fmt.Sprintf("reading embedded templates in %s: %v", root, err))
+	}
+	return files
+}
+
+// goModContent renders a go.mod requiring reqs, using a single require line
+// when there is only one dependency.
+func goModContent(modName string, reqs []requirement) string {
+	seen := map[string]bool{}
+	var unique []requirement
+	for _, r := range reqs {
+		if !seen[r.module] {
+			seen[r.module] = true
+			unique = append(unique, r)
+		}
+	}
+	reqs = unique
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].module < reqs[j].module })
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "module %s\n\ngo 1.23\n\n", modName)
+	if len(reqs) == 1 {
+		fmt.Fprintf(&b, "require %s %s\n", reqs[0].module, reqs[0].version)
+		return b.String()
+	}
+
+	b.WriteString("require (\n")
+	for _, r := range reqs {
+		fmt.Fprintf(&b, "\t%s %s\n", r.module, r.version)
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+func printTemplates() {
+	fmt.Println("\nTemplates:")
+	for _, name := range templateOrder {
+		fmt.Printf("  %-16s %s\n", name, projectTemplates[name].description)
+	}
+}
+
+// envPrefix turns a project name into a prefix for environment variables,
+// e.g. "my-app" becomes "MY_APP".
+func envPrefix(projectName string) string {
+	prefix := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, projectName)
+	if prefix == "" || (prefix[0] >= '0' && prefix[0] <= '9') {
+		prefix = "APP_" + prefix
+	}
+	return prefix
+}
+
+// isGoFile reports whether a generated file should be gofmt'ed.
+func isGoFile(p string) bool {
+	return path.Ext(p) == ".go"
+}
@@ -0,0 +1,264 @@
+package init
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/pflag"
+)
+
+//go:embed templates/theme_import.go.tmpl
+var themeImportTemplate string
+
+// themeImportData is rendered by templates/theme_import.go.tmpl.
+type themeImportData struct {
+	Name    string // palette name, as used with <NAME>_THEME
+	Var     string // exported variable holding the palette
+	Source  string
+	Format  string
+	Dark    bool
+	Palette schemePalette
+}
+
+var paletteNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+func runTheme(args []string) {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		printThemeUsage(nil)
+		Exit(0)
+	}
+	if args[0] != "import" {
+		fmt.Printf("Error: unknown theme command '%s'; only 'import' is supported\n", args[0])
+		Exit(1)
+	}
+
+	flags := pflag.NewFlagSet("theme import", pflag.ContinueOnError)
+	name := flags.String("name", "", "Palette name (default: the scheme's name)")
+	dir := flags.StringP("dir", "C", ".", "Directory inside the project whose theme package should be used")
+	force := flags.Bool("force", false, "Overwrite an existing palette file")
+	help := flags.BoolP("help", "h", false, "Show help message")
+	flags.Usage = func() { printThemeUsage(flags) }
+
+	if err := flags.Parse(args[1:]); err != nil {
+		Exit(1)
+	}
+
+	if *help || flags.NArg() < 1 {
+		printThemeUsage(flags)
+		Exit(0)
+	}
+
+	file := flags.Arg(0)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		fmt.Println("Error:", err)
+		Exit(1)
+	}
+	scheme, err := parseScheme(file, data)
+	if err != nil {
+		fmt.Println("Error:", err)
+		Exit(1)
+	}
+
+	paletteName := *name
+	if paletteName == "" {
+		paletteName = slug(scheme.name)
+	}
+	if !paletteNamePattern.MatchString(paletteName) {
+		fmt.Printf("Error: '%s' is not a valid palette name; use letters, digits, '-' and '_', starting with a letter\n", paletteName)
+		Exit(1)
+	}
+
+	modPath, err := findGoMod(*dir)
+	if err != nil {
+		fmt.Println("Error:", err)
+		Exit(1)
+	}
+	themeDir, err := findThemePackage(filepath.Dir(modPath))
+	if err != nil {
+		fmt.Println("Error:", err)
+		Exit(1)
+	}
+
+	// The _palette suffix keeps the file clear of the package's own files,
+	// and of suffixes such as _test or _windows that would leave it out of
+	// normal builds.
+	out := filepath.Join(themeDir, strings.ToLower(strings.ReplaceAll(paletteName, "-", "_"))+"_palette.go")
+	if existing, err := os.ReadFile(out); err == nil {
+		if !bytes.Contains(existing, []byte(importMarker)) {
+			fmt.Printf("Error: %s exists and does not hold an imported palette; choose another --name\n", out)
+			Exit(1)
+		}
+		if !*force {
+			fmt.Printf("Error: %s already exists. Use --force to overwrite.\n", out)
+			Exit(1)
+		}
+	}
+
+	importData := themeImportData{
+		Name:    paletteName,
+		Var:     exportedName(paletteName),
+		Source:  filepath.Base(file),
+		Format:  scheme.format,
+		Dark:    scheme.dark(),
+		Palette: scheme.palette(),
+	}
+	if err := checkPaletteName(themeDir, out, importData.Var); err != nil {
+		fmt.Println("Error:", err)
+		Exit(1)
+	}
+
+	content, err := renderGo(filepath.Base(out), themeImportTemplate, importData)
+	if err != nil {
+		fmt.Println("Error:", err)
+		Exit(1)
+	}
+	if err := os.WriteFile(out, content, 0644); err != nil {
+		fmt.Printf("Error writing %s: %v\n", out, err)
+		Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println(swatches(importData))
+
+	successMsg := style.Render("✅ Imported!")
+	fmt.Printf("\n%s %s scheme '%s' written to %s as theme.%s\n", successMsg, scheme.format, scheme.name, out, importData.Var)
+	if env := themeEnvVar(themeDir); env != "" {
+		fmt.Printf("\nSelect it with %s=%s, or make it the default in theme.go.\n", env, paletteName)
+	}
+}
+
+// importMarker is in the doc comment of every palette theme import writes.
+const importMarker = "bubbletea-init theme import"
+
+var themeEnvPattern = regexp.MustCompile(`os\.Getenv\("(\w+_THEME)"\)`)
+
+// themeEnvVar returns the environment variable the theme package reads the
+// palette name from.
+func themeEnvVar(themeDir string) string {
+	content, err := os.ReadFile(filepath.Join(themeDir, "theme.go"))
+	if err != nil {
+		return ""
+	}
+	if m := themeEnvPattern.FindSubmatch(content); m != nil {
+		return string(m[1])
+	}
+	return ""
+}
+
+func printThemeUsage(flags *pflag.FlagSet) {
+	fmt.Println("Usage: bubbletea-init theme import [flags] <file>")
+	fmt.Println("\nReads base16 YAML, Alacritty TOML or YAML, and iTerm .itermcolors schemes.")
+	if flags != nil {
+		fmt.Println("\nFlags:")
+		flags.PrintDefaults()
+	}
+}
+
+// findThemePackage returns the directory of the theme package generated into
+// the project at root.
+func findThemePackage(root string) (string, error) {
+	for _, dir := range []string{themeDir("standard"), themeDir("flat")} {
+		p := filepath.Join(root, filepath.FromSlash(dir))
+		if _, err := os.Stat(filepath.Join(p, "theme.go")); err == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("no theme package found in %s; bubbletea-init generates it in theme/ or internal/theme/", root)
+}
+
+// checkPaletteName makes sure no file of the theme package other than out
+// already declares name.
+func checkPaletteName(themeDir, out, name string) error {
+	paths, err := filepath.Glob(filepath.Join(themeDir, "*.go"))
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	for _, p := range paths {
+		if p == out || strings.HasSuffix(p, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, p, nil, 0)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %v", p, err)
+		}
+		for _, declared := range fileNames(file) {
+			if declared == name {
+				return fmt.Errorf("theme.%s is already declared in %s; choose another --name", name, filepath.Base(p))
+			}
+		}
+	}
+	return nil
+}
+
+// slug turns a scheme name such as "Tomorrow Night" into "tomorrow-night".
+func slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// exportedName turns a palette name such as "our-brand" into "OurBrand".
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' }) {
+		b.WriteString(capitalize(part))
+	}
+	return b.String()
+}
+
+// swatches previews an imported palette: a block of each color with its
+// role and value, and a title drawn the way the generated Styles draw it.
+func swatches(d themeImportData) string {
+	roles := []struct{ name, color string }{
+		{"Primary", d.Palette.Primary},
+		{"OnPrimary", d.Palette.OnPrimary},
+		{"Text", d.Palette.Text},
+		{"Muted", d.Palette.Muted},
+		{"Surface", d.Palette.Surface},
+		{"Error", d.Palette.Error},
+		{"Success", d.Palette.Success},
+		{"Warning", d.Palette.Warning},
+	}
+
+	rows := make([]string, 0, len(roles)+2)
+	for _, r := range roles {
+		block := lipgloss.NewStyle().Background(lipgloss.Color(r.color)).Render("      ")
+		label := lipgloss.NewStyle().Foreground(lipgloss.Color(r.color)).Render(fmt.Sprintf(" %-10s %s", r.name, r.color))
+		rows = append(rows, block+label)
+	}
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(d.Palette.OnPrimary)).
+		Background(lipgloss.Color(d.Palette.Primary)).
+		Padding(0, 1).
+		Render(d.Name)
+	body := lipgloss.NewStyle().Foreground(lipgloss.Color(d.Palette.Text)).Render(" Text ") +
+		lipgloss.NewStyle().Foreground(lipgloss.Color(d.Palette.Muted)).Render("muted hint")
+	rows = append(rows, "", title+body)
+
+	return lipgloss.NewStyle().Padding(0, 2).Render(strings.Join(rows, "\n"))
+}
@@ -0,0 +1,261 @@
+package tests
+
+import (
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	initialize "github.com/ConstantinBalan/bubbletea-init/pkg/init"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddComponentToBasicProject(t *testing.T) {
+	components := []struct {
+		name     string
+		field    string
+		initExpr string
+	}{
+		{"spinner", "spinner spinner.Model", "spinner.New()"},
+		{"textinput", "textinput textinput.Model", "newTextinput()"},
+		{"textarea", "textarea textarea.Model", "newTextarea()"},
+		{"list", "list list.Model", "newList()"},
+		{"table", "table table.Model", "table.New("},
+		{"viewport", "viewport viewport.Model", "viewport.New("},
+		{"progress", "progress progress.Model", "progress.New("},
+		{"paginator", "paginator paginator.Model", "paginator.New()"},
+		{"help", "usage help.Model", "help.New()"},
+		{"filepicker", "filepicker filepicker.Model", "newFilepicker()"},
+		{"timer", "timer timer.Model", "timer.New(time.Minute)"},
+		{"stopwatch", "stopwatch stopwatch.Model", "stopwatch.New()"},
+	}
+
+	// The default template already renders a help view in a field named help.
+	fieldNames := map[string]string{"help": "usage"}
+
+	for _, tt := range components {
+		t.Run(tt.name, func(t *testing.T) {
+			testDir, cleanup := setupTest(t)
+			defer cleanup()
+
+			envCleanup := setupTestEnv(t, testDir)
+			defer envCleanup()
+
+			os.Args = []string{"bubbletea-init", "addproject"}
+			initialize.Initialize()
+
+			resetFlags()
+			field := tt.name
+			if name, ok := fieldNames[tt.name]; ok {
+				field = name
+			}
+
+			os.Args = []string{"bubbletea-init", "add", tt.name, "--dir", "addproject", "--name", field}
+			initialize.Initialize()
+
+			mainFile := filepath.Join(testDir, "addproject", "main.go")
+			content, err := os.ReadFile(mainFile)
+			require.NoError(t, err)
+			mainContent := string(content)
+
+			_, err = parser.ParseFile(token.NewFileSet(), mainFile, content, 0)
+			require.NoError(t, err, "Rewritten main.go should be valid Go")
+
+			assert.Contains(t, mainContent, "github.com/charmbracelet/bubbles/"+tt.name)
+			assert.Contains(t, mainContent, tt.field, "Expected field in model struct")
+			assert.Contains(t, mainContent, tt.initExpr, "Expected field initialised in constructor")
+			assert.Contains(t, mainContent, "m."+field+".View(", "Expected component rendered in View")
+
+			modContent, err := os.ReadFile(filepath.Join(testDir, "addproject", "go.mod"))
+			require.NoError(t, err)
+			assert.Contains(t, string(modContent), "github.com/charmbracelet/bubbles")
+		})
+	}
+}
+
+func TestAddComponentWiresInitAndUpdate(t *testing.T) {
+	testDir, cleanup := setupTest(t)
+	defer cleanup()
+
+	envCleanup := setupTestEnv(t, testDir)
+	defer envCleanup()
+
+	os.Args = []string{"bubbletea-init", "--with-bubbles", "--no-deps", "wired"}
+	initialize.Initialize()
+
+	resetFlags()
+	os.Args = []string{"bubbletea-init", "add", "stopwatch", "--name", "clock", "-C", "wired"}
+	initialize.Initialize()
+
+	content, err := os.ReadFile(filepath.Join(testDir, "wired", "main.go"))
+	require.NoError(t, err)
+	mainContent := string(content)
+
+	assert.Contains(t, mainContent, "clock    stopwatch.Model", "Expected aligned field with custom name")
+	assert.Contains(t, mainContent, "clock:   stopwatch.New(),", "Expected field in initialModel literal")
+	assert.Contains(t, mainContent, "m.clock.Init(),", "Expected command appended to existing tea.Batch in Init")
+	assert.Contains(t, mainContent, "m.clock, clockCmd = m.clock.Update(msg)", "Expected component updated in Update")
+	assert.Contains(t, mainContent, "tea.Batch(cmd, clockCmd)", "Expected command returned from Update")
+	assert.Contains(t, mainContent, "type spinner struct", "Existing code should be preserved")
+}
+
+func TestAddComponentRefusals(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		goMod    string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "no model",
+			source:   "package main\n\nfunc main() {}\n",
+			args:     []string{"spinner"},
+			expected: "no Bubble Tea model found",
+		},
+		{
+			name: "model never constructed",
+			source: `package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+type model struct{}
+
+func (m model) Init() tea.Cmd                           { return nil }
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { return m, nil }
+func (m model) View() string                            { return "" }
+`,
+			args:     []string{"spinner"},
+			expected: "could not find where 'model' is constructed",
+		},
+		{
+			name: "existing field",
+			source: `package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+type model struct{ timer int }
+
+func (m model) Init() tea.Cmd                           { return nil }
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { return m, nil }
+func (m model) View() string                            { return "" }
+
+func main() { _ = model{} }
+`,
+			args:     []string{"timer"},
+			expected: "already has a field named 'timer'",
+		},
+		{
+			name:     "unknown component",
+			source:   "package main\n",
+			args:     []string{"carousel"},
+			expected: "unknown component 'carousel'",
+		},
+		{
+			name: "go.mod without a module",
+			source: `package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+type model struct{}
+
+func (m model) Init() tea.Cmd                           { return nil }
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { return m, nil }
+func (m model) View() string                            { return "" }
+
+func main() { _ = model{} }
+`,
+			goMod:    "go 1.21\n",
+			args:     []string{"spinner"},
+			expected: "go.mod has no module directive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goMod := tt.goMod
+			if goMod == "" {
+				goMod = "module example.com/refuse\n"
+			}
+			code, out := runExpectingExit(t, append([]string{"add"}, tt.args...), func(dir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte(tt.source), 0644))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644))
+			})
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, tt.expected)
+
+			content, err := os.ReadFile("main.go")
+			require.NoError(t, err)
+			assert.Equal(t, tt.source, string(content), "main.go should be left untouched")
+
+			content, err = os.ReadFile("go.mod")
+			require.NoError(t, err)
+			assert.Equal(t, goMod, string(content), "go.mod should be left untouched")
+		})
+	}
+}
+
+func TestAddComponentNextSteps(t *testing.T) {
+	testDir, cleanup := setupTest(t)
+	defer cleanup()
+
+	envCleanup := setupTestEnv(t, testDir)
+	defer envCleanup()
+
+	os.Args = []string{"bubbletea-init", "--layout", "standard", "steps"}
+	initialize.Initialize()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	defer func() { os.Stdout = oldStdout }()
+
+	resetFlags()
+	os.Args = []string{"bubbletea-init", "add", "list", "-C", filepath.Join("steps", "internal", "ui")}
+	initialize.Initialize()
+
+	w.Close()
+	outBytes, _ := io.ReadAll(r)
+	output := string(outBytes)
+
+	assert.Contains(t, output, "cd steps\n", "Expected to be told to change to the module root")
+	assert.Contains(t, output, "go run ./cmd/steps\n", "Expected the standard layout's command to be run")
+
+	content, err := os.ReadFile(filepath.Join(testDir, "steps", "internal", "ui", "model.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "DisableQuitKeybindings()", "Expected the list to leave q to the model")
+}
+
+func TestAddSeveralComponents(t *testing.T) {
+	testDir, cleanup := setupTest(t)
+	defer cleanup()
+
+	envCleanup := setupTestEnv(t, testDir)
+	defer envCleanup()
+
+	os.Args = []string{"bubbletea-init", "several"}
+	initialize.Initialize()
+
+	for _, name := range []string{"spinner", "progress", "timer"} {
+		resetFlags()
+		os.Args = []string{"bubbletea-init", "add", name, "-C", "several"}
+		initialize.Initialize()
+	}
+
+	content, err := os.ReadFile(filepath.Join(testDir, "several", "main.go"))
+	require.NoError(t, err)
+	mainContent := string(content)
+
+	spinner := strings.Index(mainContent, "m.spinner.Update(msg)")
+	progress := strings.Index(mainContent, "m.progress.Update(msg)")
+	timer := strings.Index(mainContent, "m.timer.Update(msg)")
+	assert.True(t, spinner < progress && progress < timer, "Expected Update snippets in the order the components were added")
+
+	assert.Contains(t, mainContent, "\t\"github.com/charmbracelet/bubbles/timer\"\n\ttea \"github.com/charmbracelet/bubbletea\"\n\n\t\"github.com/yourusername/several/theme\"",
+		"Expected bubbles imports with the other third-party imports, not the project's own")
+}
@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// appTemplates are the single-purpose application templates. They only
+// come in the flat layout and take none of the program option flags.
+var appTemplates = []string{"table", "todo", "tail", "file-browser", "dashboard"}
+
+func TestAppTemplatesCompile(t *testing.T) {
+	projects := [][]string{
+		{"-t", "file-browser", "--extensions", "txt", "--on-select", "status"},
+	}
+	for _, name := range appTemplates {
+		projects = append(projects, []string{"-t", name})
+	}
+
+	for _, args := range projects {
+		t.Run(strings.Join(args[1:], "_"), func(t *testing.T) {
+			requireCompiles(t, generateWithOptions(t, args))
+		})
+	}
+}
+
+func TestAppTemplateErrors(t *testing.T) {
+	for _, name := range appTemplates {
+		for _, flag := range []string{"--cli", "--mouse=cell", "--report-focus", "--layout=standard"} {
+			t.Run(name+"/"+flag, func(t *testing.T) {
+				expected := fmt.Sprintf("%s does not apply to the %s template", strings.Split(flag, "=")[0], name)
+				if flag == "--layout=standard" {
+					expected = fmt.Sprintf("the %s template does not support --layout standard", name)
+				}
+
+				code, out := runExpectingExit(t, []string{"-t", name, flag, "app"})
+				assert.Equal(t, 1, code)
+				assert.Contains(t, out, expected)
+				assert.NoDirExists(t, "app")
+			})
+		}
+	}
+}
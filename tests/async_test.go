@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncTemplate(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "async"})
+	requireValidGo(t, projectDir)
+
+	for _, file := range []string{"main.go", "fetch.go", "keys.go", "main_test.go", "fetch_test.go", "harness_test.go"} {
+		_, err := os.Stat(filepath.Join(projectDir, file))
+		assert.NoError(t, err, "Expected %s to be generated", file)
+	}
+
+	fetch, err := os.ReadFile(filepath.Join(projectDir, "fetch.go"))
+	require.NoError(t, err)
+	for _, snippet := range []string{
+		"http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)",
+		"failed.retryIn = f.backoff(attempt)",
+		"p.send(progressMsg{read: p.read, total: p.total})",
+	} {
+		assert.Contains(t, string(fetch), snippet)
+	}
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "f.send = p.Send", "Progress should be sent through the program")
+	assert.Contains(t, string(mainContent), "m.cancel()", "Quitting should cancel the download")
+	assert.Contains(t, string(mainContent), `os.Getenv("OPTS_URL")`)
+
+	fetchTest, err := os.ReadFile(filepath.Join(projectDir, "fetch_test.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(fetchTest), "httptest.NewServer")
+
+	keys, err := os.ReadFile(filepath.Join(projectDir, "keys.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(keys), `key.WithHelp("r", "download again")`)
+}
+
+func TestAsyncTemplateWithDebug(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "async", "--debug"})
+	requireValidGo(t, projectDir)
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "startDebug(initialModel(keys, f), debugConfigFromEnv())")
+	assert.Contains(t, string(mainContent), "f.send = p.Send")
+}
+
+func TestAsyncTemplateCompiles(t *testing.T) {
+	requireCompiles(t, generateWithOptions(t, []string{"-t", "async"}))
+}
+
+func TestAsyncTemplateErrors(t *testing.T) {
+	for _, flag := range []string{"--cli", "--report-focus"} {
+		t.Run(flag, func(t *testing.T) {
+			code, out := runExpectingExit(t, []string{"-t", "async", flag, "fetcher"})
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, flag+" does not apply to the async template")
+		})
+	}
+}
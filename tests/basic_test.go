@@ -62,7 +62,37 @@ func TestProjectWithBubbles(t *testing.T) {
 	// Verify main.go contains bubble components
 	content, err := os.ReadFile(mainFile)
 	require.NoError(t, err)
+	assert.Contains(t, string(content), "spinner.Model")
+	assert.Contains(t, string(content), "textinput.Model")
+	assert.Contains(t, string(content), "\"github.com/yourusername/testbubbles/theme\"", "Expected styles from the generated theme package")
+	assert.NotContains(t, string(content), "type spinner struct")
+
+	modContent, err := os.ReadFile(modFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(modContent), "github.com/charmbracelet/bubbles")
+}
+
+func TestProjectWithBubblesNoDeps(t *testing.T) {
+	testDir, cleanup := setupTest(t)
+	defer cleanup()
+
+	projectDir, err := os.MkdirTemp(testDir, "project-*")
+	require.NoError(t, err)
+
+	envCleanup := setupTestEnv(t, projectDir)
+	defer envCleanup()
+
+	os.Args = []string{"bubbletea-init", "--with-bubbles", "--no-deps", "testnodeps"}
+	initialize.Initialize()
+
+	content, err := os.ReadFile(filepath.Join(projectDir, "testnodeps", "main.go"))
+	require.NoError(t, err)
 	assert.Contains(t, string(content), "type spinner struct")
 	assert.Contains(t, string(content), "type textInput struct")
-	assert.Contains(t, string(content), "github.com/charmbracelet/lipgloss")
+	assert.NotContains(t, string(content), "\"github.com/charmbracelet/bubbles/")
+
+	modContent, err := os.ReadFile(filepath.Join(projectDir, "testnodeps", "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(modContent), "github.com/charmbracelet/lipgloss")
+	assert.NotContains(t, string(modContent), "github.com/charmbracelet/bubbles")
 }
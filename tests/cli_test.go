@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	initialize "github.com/ConstantinBalan/bubbletea-init/pkg/init"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCLIEntrypoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		mainDir string
+		runCall string
+	}{
+		{
+			name:    "default",
+			mainDir: ".",
+			runCall: "run(initialModel(keys), opts)",
+		},
+		{
+			name:    "bubbles",
+			args:    []string{"--with-bubbles"},
+			mainDir: ".",
+			runCall: "run(initialModel(keys), opts)",
+		},
+		{
+			name:    "bubbles-no-deps",
+			args:    []string{"--with-bubbles", "--no-deps"},
+			mainDir: ".",
+			runCall: "run(initialModel(), opts)",
+		},
+		{
+			name:    "multi-screen",
+			args:    []string{"-t", "multi-screen"},
+			mainDir: ".",
+			runCall: `run(newRouter("cli", newHomeScreen()), opts, tea.WithAltScreen())`,
+		},
+		{
+			name:    "standard layout",
+			args:    []string{"--layout", "standard"},
+			mainDir: "cmd/cli",
+			runCall: "run(ui.New(cfg, app.NewService(cfg)), opts)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testDir, cleanup := setupTest(t)
+			defer cleanup()
+
+			envCleanup := setupTestEnv(t, testDir)
+			defer envCleanup()
+
+			os.Args = append(append([]string{"bubbletea-init", "--cli"}, tt.args...), "cli")
+			initialize.Initialize()
+
+			projectDir := filepath.Join(testDir, "cli")
+			mainDir := filepath.Join(projectDir, filepath.FromSlash(tt.mainDir))
+
+			for _, file := range []string{"main.go", "cli.go", "cli_test.go"} {
+				path := filepath.Join(mainDir, file)
+				content, err := os.ReadFile(path)
+				require.NoError(t, err, "Expected %s to be generated", file)
+
+				_, err = parser.ParseFile(token.NewFileSet(), path, content, 0)
+				require.NoError(t, err, "%s should be valid Go", file)
+			}
+
+			mainContent, err := os.ReadFile(filepath.Join(mainDir, "main.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(mainContent), "opts := parseCommandLine()")
+			assert.Contains(t, string(mainContent), tt.runCall)
+			assert.NotContains(t, string(mainContent), "tea.NewProgram")
+
+			cliContent, err := os.ReadFile(filepath.Join(mainDir, "cli.go"))
+			require.NoError(t, err)
+			for _, snippet := range []string{
+				`var version = "dev"`,
+				`go build -ldflags "-X main.version=v1.0.0"`,
+				`pflag.NewFlagSet("cli", pflag.ContinueOnError)`,
+				`"version", "v"`,
+				`"debug"`,
+				"tea.LogToFile(opts.logFile, \"debug\")",
+				"term.IsTerminal",
+				"printPlain(os.Stdout, m)",
+			} {
+				assert.Contains(t, string(cliContent), snippet)
+			}
+
+			goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+			require.NoError(t, err)
+			assert.Contains(t, string(goMod), "github.com/spf13/pflag v1.0.5")
+			assert.Contains(t, string(goMod), "golang.org/x/term v0.6.0")
+		})
+	}
+}
+
+func TestNoCLIEntrypointByDefault(t *testing.T) {
+	testDir, cleanup := setupTest(t)
+	defer cleanup()
+
+	envCleanup := setupTestEnv(t, testDir)
+	defer envCleanup()
+
+	os.Args = []string{"bubbletea-init", "plain"}
+	initialize.Initialize()
+
+	projectDir := filepath.Join(testDir, "plain")
+	assert.NoFileExists(t, filepath.Join(projectDir, "cli.go"))
+	assert.NoFileExists(t, filepath.Join(projectDir, "cli_test.go"))
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "tea.NewProgram(initialModel(keys)).Run()")
+	assert.NotContains(t, string(mainContent), "parseCommandLine")
+
+	goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(goMod), "spf13/pflag")
+	assert.NotContains(t, string(goMod), "golang.org/x/term")
+}
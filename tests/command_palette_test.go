@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandPalette(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"--with-command-palette"})
+	requireValidGo(t, projectDir)
+
+	for _, file := range []string{"palette.go", "actions.go", "palette_test.go"} {
+		_, err := os.Stat(filepath.Join(projectDir, file))
+		assert.NoError(t, err, "Expected %s to be generated", file)
+	}
+
+	keys, err := os.ReadFile(filepath.Join(projectDir, "keys.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(keys), `key.WithKeys("ctrl+p")`)
+	assert.Contains(t, string(keys), `"palette": &k.Palette`, "The palette binding should be overridable")
+	assert.Contains(t, string(keys), "k.Palette, k.Help, k.Quit", "The help should show the palette binding")
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	for _, snippet := range []string{
+		"palette: newCommandPalette(actions)",
+		"return m, m.palette.show()",
+		"if a, ok := actionFor(m.actions, msg); ok {",
+		"return m.palette.overlay(s, m.width)",
+	} {
+		assert.Contains(t, string(mainContent), snippet)
+	}
+	assert.NotContains(t, string(mainContent), "case key.Matches(msg, m.keys.Quit):", "Keys should run through the action registry")
+
+	goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(goMod), "github.com/sahilm/fuzzy")
+}
+
+func TestWithoutCommandPalette(t *testing.T) {
+	projectDir := generateWithOptions(t, nil)
+
+	_, err := os.Stat(filepath.Join(projectDir, "palette.go"))
+	assert.True(t, os.IsNotExist(err), "palette.go should only be generated with --with-command-palette")
+
+	keys, err := os.ReadFile(filepath.Join(projectDir, "keys.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(keys), "Palette")
+}
+
+func TestCommandPaletteCompiles(t *testing.T) {
+	requireCompiles(t, generateWithOptions(t, []string{"--with-command-palette"}))
+}
+
+func TestCommandPaletteWithViewLayoutCompiles(t *testing.T) {
+	requireCompiles(t, generateWithOptions(t, []string{"--with-command-palette", "--view-layout", "split"}))
+}
+
+func TestCommandPaletteStandardLayout(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"--with-command-palette", "--layout", "standard"})
+	requireValidGo(t, projectDir)
+
+	uiDir := filepath.Join(projectDir, "internal", "ui")
+	for _, file := range []string{"palette.go", "actions.go", "palette_test.go"} {
+		content, err := os.ReadFile(filepath.Join(uiDir, file))
+		require.NoError(t, err, "Expected internal/ui/%s to be generated", file)
+		assert.Contains(t, string(content), "package ui\n")
+	}
+
+	keys, err := os.ReadFile(filepath.Join(uiDir, "keys.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(keys), `key.WithKeys("ctrl+p")`)
+
+	model, err := os.ReadFile(filepath.Join(uiDir, "model.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(model), "palette: newCommandPalette(actions)")
+	assert.Contains(t, string(model), "ctrl+p commands")
+
+	actions, err := os.ReadFile(filepath.Join(uiDir, "actions.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(actions), "toggleHelpMsg", "The standard layout has no help to toggle")
+}
+
+func TestCommandPaletteStandardLayoutCompiles(t *testing.T) {
+	requireCompiles(t, generateWithOptions(t, []string{"--with-command-palette", "--layout", "standard", "--view-layout", "split"}))
+}
+
+func TestCommandPaletteErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"other template", []string{"-t", "table", "--with-command-palette"}, "--with-command-palette only applies to the default template, not table"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := runExpectingExit(t, append(tt.args, "palette"))
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, tt.expected)
+		})
+	}
+}
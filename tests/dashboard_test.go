@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardTemplate(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "dashboard"})
+	requireValidGo(t, projectDir)
+
+	for _, file := range []string{"main.go", "panel.go", "sources.go", "keys.go", "main_test.go", "panel_test.go", "sources_test.go", "harness_test.go"} {
+		_, err := os.Stat(filepath.Join(projectDir, file))
+		assert.NoError(t, err, "Expected %s to be generated", file)
+	}
+
+	panel, err := os.ReadFile(filepath.Join(projectDir, "panel.go"))
+	require.NoError(t, err)
+	for _, snippet := range []string{
+		"tea.Tick(p.interval, func(time.Time) tea.Msg { return tickMsg{panel: id} })",
+		"func (p panel) update(msg tea.Msg) (panel, tea.Cmd)",
+		"func (p panel) stale(t time.Time) bool",
+		"func drawSparkline(values []float64, width, height int) string",
+	} {
+		assert.Contains(t, string(panel), snippet)
+	}
+
+	sources, err := os.ReadFile(filepath.Join(projectDir, "sources.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(sources), `os.Getenv("OPTS_URL")`)
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "m.panels[i], cmd = m.panels[i].update(msg)", "Panel messages should go to their panel")
+	assert.Contains(t, string(mainContent), "tea.WithAltScreen()")
+}
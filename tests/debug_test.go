@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugScaffold(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		mainDir   string
+		startCall string
+	}{
+		{
+			name:      "default",
+			mainDir:   ".",
+			startCall: "startDebug(initialModel(keys), debugConfigFromEnv())",
+		},
+		{
+			name:      "bubbles-no-deps",
+			args:      []string{"--with-bubbles", "--no-deps"},
+			mainDir:   ".",
+			startCall: "startDebug(initialModel(), debugConfigFromEnv())",
+		},
+		{
+			name:      "multi-screen",
+			args:      []string{"-t", "multi-screen"},
+			mainDir:   ".",
+			startCall: `startDebug(newRouter("opts", newHomeScreen()), debugConfigFromEnv())`,
+		},
+		{
+			name:      "standard layout",
+			args:      []string{"--layout", "standard"},
+			mainDir:   "cmd/opts",
+			startCall: "startDebug(ui.New(cfg, app.NewService(cfg)), debugConfigFromEnv())",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := generateWithOptions(t, append([]string{"--debug"}, tt.args...))
+			requireValidGo(t, projectDir)
+			mainDir := filepath.Join(projectDir, filepath.FromSlash(tt.mainDir))
+
+			mainContent, err := os.ReadFile(filepath.Join(mainDir, "main.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(mainContent), tt.startCall)
+			assert.Contains(t, string(mainContent), "stopDebug(err)\n\tif err != nil {", "The log should be closed before os.Exit")
+			assert.NotContains(t, string(mainContent), "defer stopDebug", "os.Exit skips deferred calls")
+			assert.Contains(t, string(mainContent), "tea.NewProgram(m")
+
+			debug, err := os.ReadFile(filepath.Join(mainDir, "debug.go"))
+			require.NoError(t, err, "Expected debug.go next to main.go")
+			for _, snippet := range []string{
+				`os.Getenv("OPTS_DEBUG")`,
+				`os.Getenv("OPTS_LOG_FILE")`,
+				"tea.LogToFile(cfg.logFile",
+				"slog.SetDefault(slog.New(handler))",
+				`debugPaneKey = "f12"`,
+			} {
+				assert.Contains(t, string(debug), snippet)
+			}
+
+			_, err = os.Stat(filepath.Join(mainDir, "debug_test.go"))
+			assert.NoError(t, err, "Expected debug_test.go next to debug.go")
+		})
+	}
+}
+
+func TestDebugWithCLI(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"--debug", "--cli"})
+	requireValidGo(t, projectDir)
+
+	cli, err := os.ReadFile(filepath.Join(projectDir, "cli.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(cli), "debug := debugConfigFromEnv()", "Flags should default to the environment")
+	assert.Contains(t, string(cli), "startDebug(m, debugConfig{enabled: opts.debug, logFile: opts.logFile})")
+	assert.NotContains(t, string(cli), "tea.LogToFile", "cli.go should leave logging to debug.go")
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(mainContent), "startDebug", "run starts debugging with --cli")
+}
+
+func TestNoDebugByDefault(t *testing.T) {
+	projectDir := generateWithOptions(t, nil)
+
+	_, err := os.Stat(filepath.Join(projectDir, "debug.go"))
+	assert.True(t, os.IsNotExist(err), "debug.go should only be generated with --debug")
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(mainContent), "startDebug")
+}
+
+func TestDebugCompiles(t *testing.T) {
+	for _, args := range [][]string{
+		{"--debug", "--with-bubbles"},
+		{"--debug", "--cli", "--layout", "standard"},
+	} {
+		t.Run(args[1], func(t *testing.T) {
+			requireCompiles(t, generateWithOptions(t, args))
+		})
+	}
+}
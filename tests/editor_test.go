@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditorTodo(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "todo", "--with-editor"})
+	requireValidGo(t, projectDir)
+
+	for _, file := range []string{"editor.go", "editor_test.go"} {
+		_, err := os.Stat(filepath.Join(projectDir, file))
+		assert.NoError(t, err, "Expected %s to be generated", file)
+	}
+
+	keys, err := os.ReadFile(filepath.Join(projectDir, "keys.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(keys), `"editor": &k.Editor`, "The editor binding should be overridable")
+	assert.Contains(t, string(keys), "{k.Editor, k.Pager}", "The full help should show the editor bindings")
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), `editText(t.Notes, "*.md"`)
+	assert.Contains(t, string(mainContent), "case notesEditedMsg:")
+}
+
+func TestEditorFileBrowser(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "file-browser", "--with-editor"})
+	requireValidGo(t, projectDir)
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "editFile(path, func(err error) tea.Msg")
+	assert.Contains(t, string(mainContent), "pageFile(path, func(err error) tea.Msg")
+}
+
+func TestWithoutEditor(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "todo"})
+
+	_, err := os.Stat(filepath.Join(projectDir, "editor.go"))
+	assert.True(t, os.IsNotExist(err), "editor.go should only be generated with --with-editor")
+
+	keys, err := os.ReadFile(filepath.Join(projectDir, "keys.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(keys), "Editor")
+}
+
+func TestEditorTodoCompiles(t *testing.T) {
+	requireCompiles(t, generateWithOptions(t, []string{"-t", "todo", "--with-editor"}))
+}
+
+func TestEditorFileBrowserCompiles(t *testing.T) {
+	requireCompiles(t, generateWithOptions(t, []string{"-t", "file-browser", "--with-editor", "--on-select", "status"}))
+}
+
+func TestEditorErrors(t *testing.T) {
+	code, out := runExpectingExit(t, []string{"-t", "table", "--with-editor", "editor"})
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out, "--with-editor only applies to the todo and file-browser templates, not table")
+}
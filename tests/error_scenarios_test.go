@@ -327,7 +327,12 @@ func TestNoBubblesModuleContent(t *testing.T) {
 	modContent := string(content)
 
 	assert.Contains(t, modContent, "github.com/charmbracelet/bubbletea", "Expected bubbletea dependency")
-	assert.NotContains(t, modContent, "lipgloss", "Expected NO lipgloss dependency without --with-bubbles")
+	assert.Contains(t, modContent, "github.com/charmbracelet/bubbles", "Expected bubbles dependency for key bindings and help")
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "no-bubbles-test", "main.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(mainContent), "spinner", "Expected NO example components without --with-bubbles")
+	assert.NotContains(t, string(mainContent), "textinput", "Expected NO example components without --with-bubbles")
 }
 
 func TestProjectNameInTemplate(t *testing.T) {
@@ -376,7 +381,7 @@ func TestProjectNameInBubblesTemplate(t *testing.T) {
 	mainContent := string(content)
 
 	assert.Contains(t, mainContent, projectName, "Expected project name to be rendered in bubbles template")
-	assert.Contains(t, mainContent, "type spinner struct", "Expected spinner component in bubbles template")
+	assert.Contains(t, mainContent, "spinner.Model", "Expected spinner component in bubbles template")
 }
 
 func TestForceOverwriteWithExistingProject(t *testing.T) {
@@ -439,3 +444,42 @@ func TestOutputDirWithProjectName(t *testing.T) {
 func TestTemplateParsingErrorHandled(t *testing.T) {
 	assert.True(t, true, "Template parsing error handling is in place")
 }
+
+func TestNoDepsWithoutBubbles(t *testing.T) {
+	testDir, cleanup := setupTest(t)
+	defer cleanup()
+
+	envCleanup := setupTestEnv(t, testDir)
+	defer envCleanup()
+
+	resetFlags()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	exitCode := -1
+	oldExit := initialize.Exit
+	initialize.Exit = func(code int) {
+		exitCode = code
+		panic("exit")
+	}
+	defer func() {
+		initialize.Exit = oldExit
+		os.Stdout = oldStdout
+	}()
+
+	os.Args = []string{"bubbletea-init", "--no-deps", "nodeps-project"}
+
+	func() {
+		defer func() { _ = recover() }()
+		initialize.Initialize()
+	}()
+
+	w.Close()
+	outBytes, _ := io.ReadAll(r)
+
+	assert.Equal(t, 1, exitCode, "Expected Exit(1) when --no-deps is used alone")
+	assert.Contains(t, string(outBytes), "--no-deps only applies together with --with-bubbles")
+	assert.NoDirExists(t, filepath.Join(testDir, "nodeps-project"))
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileBrowserTemplate(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "file-browser"})
+	requireValidGo(t, projectDir)
+
+	for _, file := range []string{"main.go", "picker.go", "preview.go", "action.go", "keys.go", "main_test.go", "preview_test.go", "harness_test.go"} {
+		_, err := os.Stat(filepath.Join(projectDir, file))
+		assert.NoError(t, err, "Expected %s to be generated", file)
+	}
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "allowedTypes = []string{}")
+	assert.Contains(t, string(mainContent), "showHidden = false")
+	assert.Contains(t, string(mainContent), "fmt.Println(m.chosen)", "The print action should print the chosen path on exit")
+	assert.Contains(t, string(mainContent), "tea.WithAltScreen()")
+
+	action, err := os.ReadFile(filepath.Join(projectDir, "action.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(action), "return chosenMsg(path)")
+}
+
+func TestFileBrowserTemplateFlags(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "file-browser", "--extensions", "go,.md", "--show-hidden", "--on-select", "status"})
+	requireValidGo(t, projectDir)
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), `allowedTypes = []string{".go", ".md"}`)
+	assert.Contains(t, string(mainContent), "showHidden = true")
+	assert.NotContains(t, string(mainContent), "chosen", "Only the print action keeps the chosen path")
+
+	action, err := os.ReadFile(filepath.Join(projectDir, "action.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(action), `statusMsg{text: fmt.Sprintf("Selected %s (%s)"`)
+}
+
+func TestFileBrowserTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"extensions on default", []string{"--extensions", ".go"}, "--extensions only applies to the file-browser template, not default"},
+		{"show-hidden on tail", []string{"-t", "tail", "--show-hidden"}, "--show-hidden only applies to the file-browser template, not tail"},
+		{"unknown action", []string{"-t", "file-browser", "--on-select", "open"}, "unknown --on-select action 'open'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := runExpectingExit(t, append(tt.args, "browser"))
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, tt.expected)
+		})
+	}
+}
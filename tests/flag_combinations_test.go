@@ -80,6 +80,11 @@ func TestHelpFlag(t *testing.T) {
 			assert.Equal(t, tt.exitCode, exitCode)
 			assert.Contains(t, out, "Usage: bubbletea-init [flags] <project-name>")
 			assert.Contains(t, out, "--with-bubbles")
+			assert.Contains(t, out, "--no-deps")
+			assert.Contains(t, out, "--template")
+			assert.Contains(t, out, "--layout")
+			assert.Contains(t, out, "--theme")
+			assert.Contains(t, out, "multi-screen")
 			assert.Contains(t, out, "--mod")
 			assert.Contains(t, out, "--output-dir")
 			assert.Contains(t, out, "--force")
@@ -112,8 +117,8 @@ func TestMultipleFlagCombinations(t *testing.T) {
 
 		content, err := os.ReadFile(mainFile)
 		require.NoError(t, err)
-		assert.Contains(t, string(content), "type spinner struct")
-		assert.Contains(t, string(content), "github.com/charmbracelet/lipgloss")
+		assert.Contains(t, string(content), "spinner.Model")
+		assert.Contains(t, string(content), "\"github.com/test/combo/theme\"", "Expected styles from the generated theme package")
 
 		modContent, err := os.ReadFile(modFile)
 		require.NoError(t, err)
@@ -170,8 +175,8 @@ func TestMultipleFlagCombinations(t *testing.T) {
 
 		content, err := os.ReadFile(mainFile)
 		require.NoError(t, err)
-		assert.Contains(t, string(content), "type spinner struct")
-		assert.Contains(t, string(content), "github.com/charmbracelet/lipgloss")
+		assert.Contains(t, string(content), "spinner.Model")
+		assert.Contains(t, string(content), "\"github.com/test/all/theme\"", "Expected styles from the generated theme package")
 
 		modContent, err := os.ReadFile(modFile)
 		require.NoError(t, err)
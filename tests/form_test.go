@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormTemplate(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "form"})
+	requireValidGo(t, projectDir)
+
+	for _, file := range []string{"main.go", "form.go", "keys.go", "main_test.go", "form_test.go", "harness_test.go"} {
+		_, err := os.Stat(filepath.Join(projectDir, file))
+		assert.NoError(t, err, "Expected %s to be generated", file)
+	}
+
+	form, err := os.ReadFile(filepath.Join(projectDir, "form.go"))
+	require.NoError(t, err)
+	for _, snippet := range []string{
+		"huh.NewInput()",
+		"huh.NewSelect[string]()",
+		"huh.NewConfirm()",
+		"Validate(validateName)",
+		".WithTheme(formTheme(theme.Current()))",
+	} {
+		assert.Contains(t, string(form), snippet)
+	}
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "m.form.State == huh.StateAborted", "The model should check the form's state")
+	assert.NotContains(t, string(mainContent), "tea.WithAltScreen()")
+
+	goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(goMod), "github.com/charmbracelet/huh v0.3.0")
+}
+
+func TestFormTemplateCompiles(t *testing.T) {
+	requireCompiles(t, generateWithOptions(t, []string{"-t", "form"}))
+}
+
+func TestWithForm(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		dir  string
+	}{
+		{"flat", nil, "."},
+		{"standard", []string{"--layout", "standard"}, "internal/ui"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"-t", "multi-screen", "--with-form"}, tt.args...)
+			projectDir := generateWithOptions(t, args)
+			requireValidGo(t, projectDir)
+
+			for _, file := range []string{"form.go", "form_test.go", "form_screen.go", "form_screen_test.go"} {
+				_, err := os.Stat(filepath.Join(projectDir, tt.dir, file))
+				assert.NoError(t, err, "Expected %s in %s", file, tt.dir)
+			}
+
+			home, err := os.ReadFile(filepath.Join(projectDir, tt.dir, "home.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(home), "push(newFormScreen(answers{}))")
+
+			router, err := os.ReadFile(filepath.Join(projectDir, tt.dir, "router.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(router), "if r.capturingInput() && !key.Matches(msg, r.keys.Quit)")
+
+			goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+			require.NoError(t, err)
+			assert.Contains(t, string(goMod), "github.com/charmbracelet/huh")
+		})
+	}
+}
+
+func TestWithoutForm(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "multi-screen"})
+
+	_, err := os.Stat(filepath.Join(projectDir, "form_screen.go"))
+	assert.True(t, os.IsNotExist(err), "form_screen.go should only be generated with --with-form")
+
+	for _, file := range []string{"router.go", "screen.go", "home.go"} {
+		content, err := os.ReadFile(filepath.Join(projectDir, file))
+		require.NoError(t, err)
+		assert.False(t, strings.Contains(string(content), "capturingInput") || strings.Contains(string(content), "newFormScreen"),
+			"%s should not refer to the form screen", file)
+	}
+}
+
+func TestWithFormCompiles(t *testing.T) {
+	requireCompiles(t, generateWithOptions(t, []string{"-t", "multi-screen", "--with-form"}))
+}
+
+func TestFormErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"with-form on default", []string{"--with-form"}, "--with-form only applies to the multi-screen template, not default"},
+		{"with-form on form", []string{"-t", "form", "--with-form"}, "--with-form only applies to the multi-screen template, not form"},
+		{"cli", []string{"-t", "form", "--cli"}, "--cli does not apply to the form template"},
+		{"mouse", []string{"-t", "form", "--mouse", "cell"}, "--mouse does not apply to the form template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := runExpectingExit(t, append(tt.args, "signup"))
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	initialize "github.com/ConstantinBalan/bubbletea-init/pkg/init"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedProjectTests(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		testDir  string
+		testFile string
+		snippets []string
+	}{
+		{
+			name:     "default",
+			testDir:  ".",
+			testFile: "main_test.go",
+			snippets: []string{"func TestQuitKeys(t *testing.T)", "tm.requireGolden()"},
+		},
+		{
+			name:     "bubbles",
+			args:     []string{"--with-bubbles"},
+			testDir:  ".",
+			testFile: "main_test.go",
+			snippets: []string{"func TestSubmitName(t *testing.T)", `tm.typeText("R2D2")`},
+		},
+		{
+			name:     "bubbles-no-deps",
+			args:     []string{"--with-bubbles", "--no-deps"},
+			testDir:  ".",
+			testFile: "main_test.go",
+			snippets: []string{"func TestSpinnerAdvances(t *testing.T)"},
+		},
+		{
+			name:     "multi-screen",
+			args:     []string{"-t", "multi-screen"},
+			testDir:  ".",
+			testFile: "router_test.go",
+			snippets: []string{`newRouter("test", newHomeScreen())`, "func TestNavigation(t *testing.T)"},
+		},
+		{
+			name:     "standard layout",
+			args:     []string{"--layout", "standard"},
+			testDir:  "internal/ui",
+			testFile: "model_test.go",
+			snippets: []string{"package ui", "New(cfg, app.NewService(cfg))"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testDir, cleanup := setupTest(t)
+			defer cleanup()
+
+			envCleanup := setupTestEnv(t, testDir)
+			defer envCleanup()
+
+			os.Args = append(append([]string{"bubbletea-init"}, tt.args...), "tested")
+			initialize.Initialize()
+
+			projectDir := filepath.Join(testDir, "tested")
+			pkgDir := filepath.Join(projectDir, filepath.FromSlash(tt.testDir))
+
+			for _, file := range []string{tt.testFile, "harness_test.go"} {
+				path := filepath.Join(pkgDir, file)
+				content, err := os.ReadFile(path)
+				require.NoError(t, err, "Expected %s to be generated", file)
+
+				_, err = parser.ParseFile(token.NewFileSet(), path, content, 0)
+				require.NoError(t, err, "%s should be valid Go", file)
+			}
+
+			content, err := os.ReadFile(filepath.Join(pkgDir, tt.testFile))
+			require.NoError(t, err)
+			for _, snippet := range tt.snippets {
+				assert.Contains(t, string(content), snippet)
+			}
+
+			harness, err := os.ReadFile(filepath.Join(pkgDir, "harness_test.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(harness), `flag.Bool("update"`, "Expected an -update flag for golden files")
+			assert.Contains(t, string(harness), "missing, run with -update", "Expected a missing golden file to fail the test")
+
+			modContent, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+			require.NoError(t, err)
+			assert.Contains(t, string(harness), "lipgloss.SetColorProfile(termenv.Ascii)", "Expected a forced color profile")
+			assert.Contains(t, string(modContent), "github.com/muesli/termenv")
+		})
+	}
+}
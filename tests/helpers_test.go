@@ -1,9 +1,17 @@
 package tests
 
 import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 
+	initialize "github.com/ConstantinBalan/bubbletea-init/pkg/init"
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/require"
 )
@@ -34,3 +42,102 @@ func setupTestEnv(t *testing.T, projectDir string) func() {
 		pflag.CommandLine = oldCommandLine
 	}
 }
+
+// generateWithOptions generates a project named "opts" and returns its
+// directory.
+func generateWithOptions(t *testing.T, args []string) string {
+	t.Helper()
+	testDir, cleanup := setupTest(t)
+	t.Cleanup(cleanup)
+
+	envCleanup := setupTestEnv(t, testDir)
+	t.Cleanup(envCleanup)
+
+	os.Args = append(append([]string{"bubbletea-init"}, args...), "opts")
+	initialize.Initialize()
+	return filepath.Join(testDir, "opts")
+}
+
+// runExpectingExit runs the generator with args in a fresh directory, which
+// stays the working directory until the test ends. The setup functions get
+// that directory first, to write the files the command works on. It returns
+// the code passed to Exit, or -1 if the generator didn't exit, and what it
+// printed.
+func runExpectingExit(t *testing.T, args []string, setup ...func(dir string)) (code int, out string) {
+	t.Helper()
+	testDir, cleanup := setupTest(t)
+	t.Cleanup(cleanup)
+
+	envCleanup := setupTestEnv(t, testDir)
+	t.Cleanup(envCleanup)
+
+	for _, f := range setup {
+		f(testDir)
+	}
+
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+
+	code = -1
+	oldExit := initialize.Exit
+	initialize.Exit = func(c int) {
+		code = c
+		panic(fmt.Sprintf("exit %d", c))
+	}
+	defer func() {
+		initialize.Exit = oldExit
+		os.Stdout = oldStdout
+	}()
+
+	os.Args = append([]string{"bubbletea-init"}, args...)
+	func() {
+		defer func() { _ = recover() }()
+		initialize.Initialize()
+	}()
+
+	w.Close()
+	outBytes, _ := io.ReadAll(r)
+	return code, string(outBytes)
+}
+
+// requireValidGo parses every Go file of the project at dir.
+func requireValidGo(t *testing.T, dir string) {
+	t.Helper()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+		_, err = parser.ParseFile(token.NewFileSet(), path, nil, parser.AllErrors)
+		return err
+	})
+	require.NoError(t, err)
+}
+
+// requireCompiles vets the project at dir, which also type-checks it. It
+// needs the go tool and access to the module proxy, so it skips the test with
+// -short or when dependencies cannot be downloaded.
+func requireCompiles(t *testing.T, dir string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping builds of generated projects in short mode")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := exec.Command(goTool, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+	if out, err := run("mod", "tidy"); err != nil {
+		t.Skipf("could not download dependencies: %v\n%s", err, out)
+	}
+	out, err := run("vet", "./...")
+	require.NoError(t, err, "generated project should compile:\n%s", out)
+}
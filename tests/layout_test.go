@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	initialize "github.com/ConstantinBalan/bubbletea-init/pkg/init"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandardLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		uiFiles []string
+	}{
+		{
+			name:    "default template",
+			args:    []string{"--layout", "standard"},
+			uiFiles: []string{"model.go", "styles.go", "keys.go"},
+		},
+		{
+			name:    "multi-screen template",
+			args:    []string{"--layout", "standard", "--template", "multi-screen"},
+			uiFiles: []string{"router.go", "screen.go", "home.go", "detail.go", "settings.go", "statusbar.go", "keys.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testDir, cleanup := setupTest(t)
+			defer cleanup()
+
+			envCleanup := setupTestEnv(t, testDir)
+			defer envCleanup()
+
+			os.Args = append([]string{"bubbletea-init", "--mod", "example.com/acme/tool"}, append(tt.args, "tool")...)
+			initialize.Initialize()
+
+			projectDir := filepath.Join(testDir, "tool")
+			assert.NoFileExists(t, filepath.Join(projectDir, "main.go"), "Standard layout should not have a root main.go")
+
+			files := []string{"cmd/tool/main.go", "internal/config/config.go", "internal/app/app.go"}
+			for _, f := range tt.uiFiles {
+				files = append(files, "internal/ui/"+f)
+			}
+
+			for _, file := range files {
+				path := filepath.Join(projectDir, filepath.FromSlash(file))
+				content, err := os.ReadFile(path)
+				require.NoError(t, err, "Expected %s to be generated", file)
+
+				parsed, err := parser.ParseFile(token.NewFileSet(), path, content, parser.ImportsOnly)
+				require.NoError(t, err, "%s should be valid Go", file)
+
+				expectedPkg := filepath.Base(filepath.Dir(path))
+				if filepath.Dir(file) == filepath.FromSlash("cmd/tool") {
+					expectedPkg = "main"
+				}
+				assert.Equal(t, expectedPkg, parsed.Name.Name, "Unexpected package name in %s", file)
+			}
+
+			mainContent, err := os.ReadFile(filepath.Join(projectDir, "cmd", "tool", "main.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(mainContent), `"example.com/acme/tool/internal/app"`)
+			assert.Contains(t, string(mainContent), `"example.com/acme/tool/internal/config"`)
+			assert.Contains(t, string(mainContent), `"example.com/acme/tool/internal/ui"`)
+			assert.Contains(t, string(mainContent), "ui.New(cfg, app.NewService(cfg))")
+
+			configContent, err := os.ReadFile(filepath.Join(projectDir, "internal", "config", "config.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(configContent), "TOOL_ITEMS")
+
+			modContent, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+			require.NoError(t, err)
+			assert.Contains(t, string(modContent), "module example.com/acme/tool")
+			assert.Contains(t, string(modContent), "github.com/charmbracelet/bubbles")
+		})
+	}
+}
+
+func TestFlatLayoutIsDefault(t *testing.T) {
+	testDir, cleanup := setupTest(t)
+	defer cleanup()
+
+	envCleanup := setupTestEnv(t, testDir)
+	defer envCleanup()
+
+	os.Args = []string{"bubbletea-init", "-t", "multi-screen", "flat"}
+	initialize.Initialize()
+
+	assert.FileExists(t, filepath.Join(testDir, "flat", "main.go"))
+	assert.FileExists(t, filepath.Join(testDir, "flat", "router.go"))
+	assert.NoDirExists(t, filepath.Join(testDir, "flat", "internal"))
+
+	content, err := os.ReadFile(filepath.Join(testDir, "flat", "router.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "package main")
+	assert.NotContains(t, string(content), "func New(")
+}
+
+func TestLayoutErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "unknown layout",
+			args:     []string{"--layout", "nested", "proj"},
+			expected: "unknown layout 'nested'",
+		},
+		{
+			name:     "template without standard layout",
+			args:     []string{"--with-bubbles", "--layout", "standard", "proj"},
+			expected: "the bubbles template does not support --layout standard",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := runExpectingExit(t, tt.args)
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, tt.expected)
+			assert.NoDirExists(t, "proj")
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownViewerTemplate(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "markdown-viewer"})
+	requireValidGo(t, projectDir)
+
+	for _, file := range []string{"main.go", "markdown.go", "sample.md", "keys.go", "main_test.go", "markdown_test.go", "harness_test.go"} {
+		_, err := os.Stat(filepath.Join(projectDir, file))
+		assert.NoError(t, err, "Expected %s to be generated", file)
+	}
+
+	sample, err := os.ReadFile(filepath.Join(projectDir, "sample.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(sample), "# opts", "The sample should be titled after the project")
+	assert.Contains(t, string(sample), "`OPTS_STYLE`")
+
+	markdown, err := os.ReadFile(filepath.Join(projectDir, "markdown.go"))
+	require.NoError(t, err)
+	for _, snippet := range []string{
+		"//go:embed sample.md",
+		"glamour.WithWordWrap(width)",
+		`os.Getenv("OPTS_STYLE")`,
+		"glamour.DarkStyle, glamour.LightStyle, glamour.NoTTYStyle",
+	} {
+		assert.Contains(t, string(markdown), snippet)
+	}
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "m.rewrap()", "The document should be wrapped again on resize")
+	assert.Contains(t, string(mainContent), "tea.WithAltScreen()")
+
+	keys, err := os.ReadFile(filepath.Join(projectDir, "keys.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(keys), `key.WithHelp("/", "search")`)
+
+	goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(goMod), "github.com/charmbracelet/glamour v0.7.0")
+}
+
+func TestMarkdownViewerTemplateCompiles(t *testing.T) {
+	requireCompiles(t, generateWithOptions(t, []string{"-t", "markdown-viewer"}))
+}
+
+func TestMarkdownViewerTemplateErrors(t *testing.T) {
+	for _, flag := range []string{"--cli", "--report-focus"} {
+		t.Run(flag, func(t *testing.T) {
+			code, out := runExpectingExit(t, []string{"-t", "markdown-viewer", flag, "docs"})
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, flag+" does not apply to the markdown-viewer template")
+		})
+	}
+}
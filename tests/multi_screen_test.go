@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	initialize "github.com/ConstantinBalan/bubbletea-init/pkg/init"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiScreenTemplate(t *testing.T) {
+	testDir, cleanup := setupTest(t)
+	defer cleanup()
+
+	envCleanup := setupTestEnv(t, testDir)
+	defer envCleanup()
+
+	os.Args = []string{"bubbletea-init", "--template", "multi-screen", "screens"}
+	initialize.Initialize()
+
+	projectDir := filepath.Join(testDir, "screens")
+	files := map[string][]string{
+		"main.go":      {"newRouter(\"screens\", newHomeScreen()), tea.WithAltScreen()"},
+		"screen.go":    {"type screen interface", "pushMsg", "popMsg", "replaceMsg"},
+		"router.go":    {"stack  []screen", "case tea.WindowSizeMsg:", "func (r *router) resizeAll() tea.Cmd"},
+		"statusbar.go": {"func (r router) statusBar() string"},
+		"keys.go":      {"type globalKeyMap struct"},
+		"home.go":      {"type homeKeyMap struct", "push(newDetailScreen("},
+		"detail.go":    {"type detailKeyMap struct", "replace(newDetailScreen("},
+		"settings.go":  {"type settingsKeyMap struct", "pop)"},
+	}
+
+	for file, snippets := range files {
+		path := filepath.Join(projectDir, file)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err, "Expected %s to be generated", file)
+
+		_, err = parser.ParseFile(token.NewFileSet(), path, content, 0)
+		require.NoError(t, err, "%s should be valid Go", file)
+
+		for _, snippet := range snippets {
+			assert.Contains(t, string(content), snippet, "Expected %q in %s", snippet, file)
+		}
+	}
+
+	modContent, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(modContent), "github.com/charmbracelet/bubbles")
+	assert.Contains(t, string(modContent), "github.com/charmbracelet/lipgloss")
+}
+
+func TestTemplateFlagErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "unknown template",
+			args:     []string{"--template", "carousel", "proj"},
+			expected: "unknown template 'carousel'",
+		},
+		{
+			name:     "with-bubbles and another template",
+			args:     []string{"--with-bubbles", "-t", "multi-screen", "proj"},
+			expected: "--with-bubbles cannot be combined with --template multi-screen",
+		},
+		{
+			name:     "no-deps with another template",
+			args:     []string{"--no-deps", "-t", "multi-screen", "proj"},
+			expected: "--no-deps only applies together with --with-bubbles",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := runExpectingExit(t, tt.args)
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, tt.expected)
+			assert.NoDirExists(t, "proj")
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	initialize "github.com/ConstantinBalan/bubbletea-init/pkg/init"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewComponent(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		pkgDir     string
+		pkgName    string
+		importPath string
+	}{
+		{
+			name:       "default package",
+			args:       []string{"Sidebar"},
+			pkgDir:     "internal/ui/sidebar",
+			pkgName:    "sidebar",
+			importPath: "github.com/acme/widgets/internal/ui/sidebar",
+		},
+		{
+			name:       "custom package",
+			args:       []string{"FileList", "--pkg", "internal/ui/files"},
+			pkgDir:     "internal/ui/files",
+			pkgName:    "files",
+			importPath: "github.com/acme/widgets/internal/ui/files",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testDir, cleanup := setupTest(t)
+			defer cleanup()
+
+			envCleanup := setupTestEnv(t, testDir)
+			defer envCleanup()
+
+			os.Args = []string{"bubbletea-init", "--mod", "github.com/acme/widgets", "widgets"}
+			initialize.Initialize()
+
+			resetFlags()
+			os.Args = append([]string{"bubbletea-init", "new", "component", "-C", "widgets"}, tt.args...)
+			initialize.Initialize()
+
+			pkgDir := filepath.Join(testDir, "widgets", filepath.FromSlash(tt.pkgDir))
+			for _, file := range []string{tt.pkgName + ".go", "keymap.go", tt.pkgName + "_test.go"} {
+				path := filepath.Join(pkgDir, file)
+				content, err := os.ReadFile(path)
+				require.NoError(t, err, "Expected %s to be generated", file)
+
+				_, err = parser.ParseFile(token.NewFileSet(), path, content, 0)
+				require.NoError(t, err, "%s should be valid Go", file)
+			}
+
+			model, err := os.ReadFile(filepath.Join(pkgDir, tt.pkgName+".go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(model), "package "+tt.pkgName)
+			assert.Contains(t, string(model), "type Model struct")
+			assert.Contains(t, string(model), "func New(opts ...Option) Model")
+			assert.Contains(t, string(model), "func (m Model) Update(msg tea.Msg) (Model, tea.Cmd)")
+			assert.Contains(t, string(model), "type SelectedMsg struct")
+			assert.Contains(t, string(model), `"github.com/acme/widgets/theme"`, "Expected the component to use the project's theme")
+			assert.Contains(t, string(model), "theme.NewStyles(theme.Current())")
+			assert.NotContains(t, string(model), "lipgloss.Color(", "Colors should come from the theme")
+
+			keys, err := os.ReadFile(filepath.Join(pkgDir, "keymap.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(keys), "func (k KeyMap) ShortHelp() []key.Binding")
+			assert.Contains(t, string(keys), "func (k KeyMap) FullHelp() [][]key.Binding")
+
+			test, err := os.ReadFile(filepath.Join(pkgDir, tt.pkgName+"_test.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(test), `"`+tt.importPath+`"`, "Test should import the package by its module path")
+			assert.Contains(t, string(test), "func TestUpdate(t *testing.T)")
+
+			modContent, err := os.ReadFile(filepath.Join(testDir, "widgets", "go.mod"))
+			require.NoError(t, err)
+			assert.Contains(t, string(modContent), "github.com/charmbracelet/bubbles")
+			assert.Contains(t, string(modContent), "github.com/charmbracelet/lipgloss")
+		})
+	}
+}
+
+func TestNewComponentErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		existing string
+		expected string
+	}{
+		{
+			name:     "invalid name",
+			args:     []string{"component", "side-bar"},
+			expected: "is not a valid component name",
+		},
+		{
+			name:     "unknown generator",
+			args:     []string{"screen", "Home"},
+			expected: "unknown generator 'screen'",
+		},
+		{
+			name:     "existing package",
+			args:     []string{"component", "Sidebar"},
+			existing: "internal/ui/sidebar/sidebar.go",
+			expected: "already exists. Use --force to overwrite.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := runExpectingExit(t, append([]string{"new"}, tt.args...), func(dir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/errs\n"), 0644))
+				if tt.existing != "" {
+					existing := filepath.Join(dir, filepath.FromSlash(tt.existing))
+					require.NoError(t, os.MkdirAll(filepath.Dir(existing), 0755))
+					require.NoError(t, os.WriteFile(existing, []byte("package sidebar\n"), 0644))
+				}
+			})
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlays(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "multi-screen", "--with-overlays"})
+	requireValidGo(t, projectDir)
+
+	for _, file := range []string{"overlay.go", "dialog.go", "toast.go", "place.go", "overlay_test.go"} {
+		_, err := os.Stat(filepath.Join(projectDir, "overlay", file))
+		assert.NoError(t, err, "Expected overlay/%s to be generated", file)
+	}
+
+	router, err := os.ReadFile(filepath.Join(projectDir, "router.go"))
+	require.NoError(t, err)
+	for _, snippet := range []string{
+		`"github.com/yourusername/opts/overlay"`,
+		"overlay: overlay.New(),",
+		"if r.overlay.Handles(msg) {",
+		"return r.overlay.View(view, r.width, r.height)",
+	} {
+		assert.Contains(t, string(router), snippet)
+	}
+
+	home, err := os.ReadFile(filepath.Join(projectDir, "home.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(home), "overlay.Confirm(question, deleteItemMsg{index: s.cursor})")
+	assert.Contains(t, string(home), "overlay.Prompt(")
+
+	goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(goMod), "github.com/muesli/reflow")
+}
+
+func TestOverlaysStandardLayout(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "multi-screen", "--with-overlays", "--layout", "standard"})
+	requireValidGo(t, projectDir)
+
+	_, err := os.Stat(filepath.Join(projectDir, "internal", "overlay", "overlay.go"))
+	assert.NoError(t, err, "The overlay package should go in internal/")
+
+	router, err := os.ReadFile(filepath.Join(projectDir, "internal", "ui", "router.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(router), `"github.com/yourusername/opts/internal/overlay"`)
+}
+
+func TestWithoutOverlays(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "multi-screen"})
+
+	_, err := os.Stat(filepath.Join(projectDir, "overlay"))
+	assert.True(t, os.IsNotExist(err), "The overlay package should only be generated with --with-overlays")
+
+	router, err := os.ReadFile(filepath.Join(projectDir, "router.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(router), "overlay")
+}
+
+func TestOverlaysCompile(t *testing.T) {
+	requireCompiles(t, generateWithOptions(t, []string{"-t", "multi-screen", "--with-overlays", "--inline", "--with-form"}))
+}
+
+func TestOverlaysStandardLayoutCompiles(t *testing.T) {
+	requireCompiles(t, generateWithOptions(t, []string{"-t", "multi-screen", "--with-overlays", "--layout", "standard"}))
+}
+
+func TestOverlaysDefaultTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		model   string
+		overlay string
+	}{
+		{"flat", nil, "main.go", "overlay"},
+		{"standard layout", []string{"--layout", "standard"}, "internal/ui/model.go", "internal/overlay"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := generateWithOptions(t, append([]string{"--with-overlays"}, tt.args...))
+			requireValidGo(t, projectDir)
+
+			_, err := os.Stat(filepath.Join(projectDir, filepath.FromSlash(tt.overlay), "overlay.go"))
+			assert.NoError(t, err, "Expected the overlay package in %s", tt.overlay)
+
+			model, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(tt.model)))
+			require.NoError(t, err)
+			for _, snippet := range []string{
+				`"github.com/yourusername/opts/` + tt.overlay + `"`,
+				"overlay: overlay.New(),",
+				"if m.overlay.Handles(msg) {",
+				"return m, confirmQuit",
+				`overlay.Confirm("Quit opts?", tea.QuitMsg{})`,
+			} {
+				assert.Contains(t, string(model), snippet)
+			}
+		})
+	}
+}
+
+func TestOverlaysWithCommandPalette(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"--with-overlays", "--with-command-palette"})
+	requireValidGo(t, projectDir)
+
+	actions, err := os.ReadFile(filepath.Join(projectDir, "actions.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(actions), `{name: "Quit", binding: keys.Quit, cmd: confirmQuit}`, "The palette's Quit action should ask first")
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "s = m.palette.overlay(s, m.width)")
+}
+
+func TestOverlaysDefaultTemplateCompiles(t *testing.T) {
+	for _, args := range [][]string{
+		{"--with-overlays", "--with-command-palette"},
+		{"--with-overlays", "--layout", "standard", "--view-layout", "split"},
+	} {
+		t.Run(strings.Join(args[1:], "_"), func(t *testing.T) {
+			requireCompiles(t, generateWithOptions(t, args))
+		})
+	}
+}
+
+func TestOverlaysErrors(t *testing.T) {
+	code, out := runExpectingExit(t, []string{"-t", "table", "--with-overlays", "overlays"})
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out, "--with-overlays only applies to the default and multi-screen templates, not table")
+}
+
+func TestOverlaysSharePlaceWithCommandPalette(t *testing.T) {
+	placeFunc := func(path string) string {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		i := strings.Index(string(content), "// place draws")
+		require.NotEqual(t, -1, i, "Expected place in %s", filepath.Base(path))
+		return string(content[i:])
+	}
+
+	overlays := generateWithOptions(t, []string{"-t", "multi-screen", "--with-overlays"})
+	palette := generateWithOptions(t, []string{"--with-command-palette"})
+	assert.Equal(t, placeFunc(filepath.Join(overlays, "overlay", "place.go")), placeFunc(filepath.Join(palette, "palette.go")),
+		"The overlays and the command palette should draw views over each other with the same code")
+}
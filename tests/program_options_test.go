@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// optionTemplates are the template and layout combinations that take
+// program options, with the file holding their tea.NewProgram call.
+var optionTemplates = []struct {
+	name     string
+	args     []string
+	mainFile string
+}{
+	{"default", nil, "main.go"},
+	{"bubbles", []string{"--with-bubbles"}, "main.go"},
+	{"bubbles-no-deps", []string{"--with-bubbles", "--no-deps"}, "main.go"},
+	{"multi-screen", []string{"-t", "multi-screen"}, "main.go"},
+	{"standard", []string{"--layout", "standard"}, "cmd/opts/main.go"},
+	{"standard multi-screen", []string{"--layout", "standard", "-t", "multi-screen"}, "cmd/opts/main.go"},
+}
+
+// optionCombinations returns every combination of the program option flags.
+func optionCombinations() [][]string {
+	var combos [][]string
+	for _, screen := range [][]string{nil, {"--alt-screen"}, {"--inline"}} {
+		for _, mouse := range [][]string{nil, {"--mouse", "cell"}, {"--mouse", "all"}} {
+			for _, focus := range [][]string{nil, {"--report-focus"}} {
+				combo := append(append(append([]string{}, screen...), mouse...), focus...)
+				combos = append(combos, combo)
+			}
+		}
+	}
+	return combos
+}
+
+func TestProgramOptionCombinations(t *testing.T) {
+	for _, tmpl := range optionTemplates {
+		for _, combo := range optionCombinations() {
+			name := tmpl.name + "/" + strings.Join(append([]string{"none"}, combo...), "_")
+			t.Run(name, func(t *testing.T) {
+				projectDir := generateWithOptions(t, append(append([]string{}, tmpl.args...), combo...))
+				requireValidGo(t, projectDir)
+
+				mainContent, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(tmpl.mainFile)))
+				require.NoError(t, err)
+
+				joined := strings.Join(combo, " ")
+				altScreen := strings.Contains(joined, "--alt-screen") ||
+					(strings.Contains(tmpl.name, "multi-screen") && !strings.Contains(joined, "--inline"))
+				assert.Equal(t, altScreen, strings.Contains(string(mainContent), "tea.WithAltScreen()"))
+				assert.Equal(t, strings.Contains(joined, "--mouse cell"), strings.Contains(string(mainContent), "tea.WithMouseCellMotion()"))
+				assert.Equal(t, strings.Contains(joined, "--mouse all"), strings.Contains(string(mainContent), "tea.WithMouseAllMotion()"))
+				assert.Equal(t, strings.Contains(joined, "--report-focus"), strings.Contains(string(mainContent), "tea.WithReportFocus()"))
+			})
+		}
+	}
+}
+
+func TestProgramOptionMessageHandling(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		modelFile string
+		testFile  string
+	}{
+		{"default", nil, "main.go", "main_test.go"},
+		{"bubbles", []string{"--with-bubbles"}, "main.go", "main_test.go"},
+		{"bubbles-no-deps", []string{"--with-bubbles", "--no-deps"}, "main.go", "main_test.go"},
+		{"standard", []string{"--layout", "standard"}, "internal/ui/model.go", "internal/ui/model_test.go"},
+		{"multi-screen", []string{"-t", "multi-screen"}, "router.go", "router_test.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := generateWithOptions(t, append(tt.args, "--mouse", "cell", "--report-focus"))
+
+			model, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(tt.modelFile)))
+			require.NoError(t, err)
+			assert.Contains(t, string(model), "case tea.FocusMsg:")
+			assert.Contains(t, string(model), "case tea.BlurMsg:")
+
+			tests, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(tt.testFile)))
+			require.NoError(t, err)
+			assert.Contains(t, string(tests), "func TestMouse(t *testing.T)")
+			assert.Contains(t, string(tests), "func TestFocus(t *testing.T)")
+
+			goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+			require.NoError(t, err)
+			assert.Contains(t, string(goMod), "github.com/charmbracelet/bubbletea v1.1.0", "Focus reporting needs Bubble Tea v1.1")
+		})
+	}
+
+	t.Run("mouse without focus", func(t *testing.T) {
+		projectDir := generateWithOptions(t, []string{"-t", "multi-screen", "--mouse", "all"})
+
+		home, err := os.ReadFile(filepath.Join(projectDir, "home.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(home), "case tea.MouseMsg:")
+
+		router, err := os.ReadFile(filepath.Join(projectDir, "router.go"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(router), "FocusMsg")
+
+		goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+		require.NoError(t, err)
+		assert.Contains(t, string(goMod), "github.com/charmbracelet/bubbletea v0.25.0")
+	})
+
+	t.Run("inline multi-screen", func(t *testing.T) {
+		projectDir := generateWithOptions(t, []string{"-t", "multi-screen", "--inline"})
+
+		router, err := os.ReadFile(filepath.Join(projectDir, "router.go"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(router), "Height(r.contentHeight())", "Inline programs should not fill the window")
+	})
+}
+
+// TestProgramOptionsCompile builds and vets projects generated with every
+// combination of program options.
+func TestProgramOptionsCompile(t *testing.T) {
+	for _, tmpl := range optionTemplates {
+		for _, combo := range optionCombinations() {
+			name := tmpl.name + "/" + strings.Join(append([]string{"none"}, combo...), "_")
+			t.Run(name, func(t *testing.T) {
+				projectDir := generateWithOptions(t, append(append([]string{}, tmpl.args...), combo...))
+				requireCompiles(t, projectDir)
+			})
+		}
+	}
+}
+
+func TestProgramOptionErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"alt screen and inline", []string{"--alt-screen", "--inline"}, "--alt-screen and --inline cannot be combined"},
+		{"unknown mouse mode", []string{"--mouse", "hover"}, "unknown mouse mode 'hover'. Available modes: cell, all"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := runExpectingExit(t, append(tt.args, "opts"))
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, tt.expected)
+			assert.NoDirExists(t, "opts")
+		})
+	}
+}
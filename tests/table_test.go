@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableTemplate(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "table"})
+	requireValidGo(t, projectDir)
+
+	for _, file := range []string{"main.go", "data.go", "sample.csv", "keys.go", "main_test.go", "data_test.go", "harness_test.go"} {
+		_, err := os.Stat(filepath.Join(projectDir, file))
+		assert.NoError(t, err, "Expected %s to be generated", file)
+	}
+
+	data, err := os.ReadFile(filepath.Join(projectDir, "data.go"))
+	require.NoError(t, err)
+	for _, snippet := range []string{
+		"//go:embed sample.csv",
+		"csv.NewReader(r).ReadAll()",
+		"func readJSON(name string, r io.Reader) (dataset, error)",
+		"fuzzy.FindNoSort(query, lines)",
+		"func autosize(",
+	} {
+		assert.Contains(t, string(data), snippet)
+	}
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "table.WithKeyMap(tk)", "The table should move with the generated key map")
+	assert.Contains(t, string(mainContent), "tea.WithAltScreen()")
+
+	goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(goMod), "github.com/sahilm/fuzzy v0.1.1")
+}
@@ -0,0 +1,42 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailTemplate(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "tail"})
+	requireValidGo(t, projectDir)
+
+	for _, file := range []string{"main.go", "source.go", "ring.go", "keys.go", "main_test.go", "source_test.go", "ring_test.go", "harness_test.go"} {
+		_, err := os.Stat(filepath.Join(projectDir, file))
+		assert.NoError(t, err, "Expected %s to be generated", file)
+	}
+
+	source, err := os.ReadFile(filepath.Join(projectDir, "source.go"))
+	require.NoError(t, err)
+	for _, snippet := range []string{
+		"func followFile(path string) readFunc",
+		"exec.CommandContext(ctx, name, args...)",
+		"func waitForLines(s *stream) tea.Cmd",
+		"func (s *stream) stop()",
+		`errors.New("usage: opts [FILE | -- COMMAND [ARG...]]")`,
+	} {
+		assert.Contains(t, string(source), snippet)
+	}
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "return m, waitForLines(m.stream)", "The model should keep listening after each batch")
+	assert.Contains(t, string(mainContent), "m.stream.stop()", "Quitting should stop the stream")
+	assert.Contains(t, string(mainContent), "tea.WithAltScreen()")
+
+	goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(goMod), "github.com/muesli/reflow v0.3.0")
+}
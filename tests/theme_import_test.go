@@ -0,0 +1,362 @@
+package tests
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	initialize "github.com/ConstantinBalan/bubbletea-init/pkg/init"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const base16Scheme = `scheme: "Ocean Deep"
+author: "Someone"
+base00: "2b303b"
+base01: "343d46"
+base02: "4f5b66"
+base03: "65737e"
+base04: "a7adba"
+base05: "c0c5ce"
+base06: "dfe1e8"
+base07: "eff1f5"
+base08: "bf616a"
+base09: "d08770"
+base0A: "ebcb8b"
+base0B: "a3be8c"
+base0C: "96b5b4"
+base0D: "8fa1b3"
+base0E: "b48ead"
+base0F: "ab7967"
+`
+
+const alacrittyTOML = `[colors.primary]
+background = "#fdf6e3"
+foreground = "#657b83"
+
+[colors.normal]
+black = "#073642"
+red = "#dc322f"
+green = "#859900"
+yellow = "#b58900"
+blue = "#268bd2"
+magenta = "#d33682"
+cyan = "#2aa198"
+white = "#eee8d5"
+
+[colors.bright]
+black = "#002b36"
+red = "#cb4b16"
+green = "#586e75"
+yellow = "#657b83"
+blue = "#839496"
+magenta = "#6c71c4"
+cyan = "#93a1a1"
+white = "#fdf6e3"
+`
+
+const alacrittyYAML = `colors:
+  primary:
+    background: '0x1d1f21'
+    foreground: '0xc5c8c6'
+  normal:
+    black:   '0x1d1f21'
+    red:     '0xcc6666'
+    green:   '0xb5bd68'
+    yellow:  '0xf0c674'
+    blue:    '0x81a2be'
+    magenta: '0xb294bb'
+    cyan:    '0x8abeb7'
+    white:   '0xc5c8c6'
+  bright:
+    black:   '0x666666'
+    red:     '0xd54e53'
+    green:   '0xb9ca4a'
+    yellow:  '0xe7c547'
+    blue:    '0x7aa6da'
+    magenta: '0xc397d8'
+    cyan:    '0x70c0b1'
+    white:   '0xeaeaea'
+`
+
+// itermScheme returns an .itermcolors property list whose ANSI colors are
+// all ansi, on a black background with a white foreground.
+func itermScheme(ansi [3]float64) string {
+	color := func(name string, rgb [3]float64) string {
+		return fmt.Sprintf(`	<key>%s</key>
+	<dict>
+		<key>Alpha Component</key>
+		<real>1</real>
+		<key>Blue Component</key>
+		<real>%g</real>
+		<key>Color Space</key>
+		<string>sRGB</string>
+		<key>Green Component</key>
+		<real>%g</real>
+		<key>Red Component</key>
+		<real>%g</real>
+	</dict>
+`, name, rgb[2], rgb[1], rgb[0])
+	}
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	b.WriteString(color("Background Color", [3]float64{0, 0, 0}))
+	b.WriteString(color("Foreground Color", [3]float64{1, 1, 1}))
+	for i := 0; i < 16; i++ {
+		b.WriteString(color(fmt.Sprintf("Ansi %d Color", i), ansi))
+	}
+	b.WriteString("</dict>\n</plist>\n")
+	return b.String()
+}
+
+func TestThemeImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		args     []string
+		output   string
+		variable string
+		colors   []string
+	}{
+		{
+			name:     "base16 yaml",
+			file:     "ocean.yaml",
+			content:  base16Scheme,
+			output:   "ocean_deep_palette.go",
+			variable: "OceanDeep",
+			colors:   []string{`Name:      "ocean-deep"`, `Primary:   lipgloss.AdaptiveColor{Light: "#8FA1B3", Dark: "#8FA1B3"}`, `Surface:   lipgloss.AdaptiveColor{Light: "#343D46"`},
+		},
+		{
+			name:     "alacritty toml",
+			file:     "brand.toml",
+			content:  alacrittyTOML,
+			args:     []string{"--name", "ourbrand"},
+			output:   "ourbrand_palette.go",
+			variable: "Ourbrand",
+			colors:   []string{`Text:      lipgloss.AdaptiveColor{Light: "#657B83"`, "single light variant"},
+		},
+		{
+			name:     "alacritty yaml",
+			file:     "tomorrow.yml",
+			content:  alacrittyYAML,
+			args:     []string{"--name", "tomorrow-night"},
+			output:   "tomorrow_night_palette.go",
+			variable: "TomorrowNight",
+			colors:   []string{`Error:     lipgloss.AdaptiveColor{Light: "#CC6666"`, "single dark variant"},
+		},
+		{
+			name:     "iterm",
+			file:     "Night Owl.itermcolors",
+			content:  itermScheme([3]float64{1, 0.5, 0}),
+			output:   "night_owl_palette.go",
+			variable: "NightOwl",
+			colors:   []string{`OnPrimary: lipgloss.AdaptiveColor{Light: "#000000"`, `Primary:   lipgloss.AdaptiveColor{Light: "#FF8000"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testDir, cleanup := setupTest(t)
+			defer cleanup()
+
+			envCleanup := setupTestEnv(t, testDir)
+			defer envCleanup()
+
+			os.Args = []string{"bubbletea-init", "branded"}
+			initialize.Initialize()
+
+			schemeFile := filepath.Join(testDir, tt.file)
+			require.NoError(t, os.WriteFile(schemeFile, []byte(tt.content), 0644))
+
+			resetFlags()
+			os.Args = append([]string{"bubbletea-init", "theme", "import", schemeFile, "-C", "branded"}, tt.args...)
+			initialize.Initialize()
+
+			path := filepath.Join(testDir, "branded", "theme", tt.output)
+			content, err := os.ReadFile(path)
+			require.NoError(t, err, "Expected %s to be written", tt.output)
+
+			_, err = parser.ParseFile(token.NewFileSet(), path, content, 0)
+			require.NoError(t, err, "Generated palette should be valid Go")
+
+			assert.Contains(t, string(content), "package theme")
+			assert.Contains(t, string(content), "var "+tt.variable+" = Palette{")
+			assert.Contains(t, string(content), "Palettes["+tt.variable+".Name] = "+tt.variable)
+			for _, c := range tt.colors {
+				assert.Contains(t, string(content), c)
+			}
+		})
+	}
+}
+
+func TestThemeImportStandardLayout(t *testing.T) {
+	testDir, cleanup := setupTest(t)
+	defer cleanup()
+
+	envCleanup := setupTestEnv(t, testDir)
+	defer envCleanup()
+
+	os.Args = []string{"bubbletea-init", "--layout", "standard", "layered"}
+	initialize.Initialize()
+
+	schemeFile := filepath.Join(testDir, "ocean.yaml")
+	require.NoError(t, os.WriteFile(schemeFile, []byte(base16Scheme), 0644))
+
+	resetFlags()
+	os.Args = []string{"bubbletea-init", "theme", "import", schemeFile, "--name", "ocean", "-C", filepath.Join("layered", "internal", "ui")}
+	initialize.Initialize()
+
+	assert.FileExists(t, filepath.Join(testDir, "layered", "internal", "theme", "ocean_palette.go"))
+}
+
+// TestThemeImportNames imports palettes named after a file of the theme
+// package, or with a suffix Go treats specially, and checks the project
+// still builds with the palette registered.
+func TestThemeImportNames(t *testing.T) {
+	for _, name := range []string{"theme", "foo_test"} {
+		t.Run(name, func(t *testing.T) {
+			projectDir := generateWithOptions(t, nil)
+			styles, err := os.ReadFile(filepath.Join(projectDir, "theme", "styles.go"))
+			require.NoError(t, err)
+
+			schemeFile := filepath.Join(filepath.Dir(projectDir), "ocean.yaml")
+			require.NoError(t, os.WriteFile(schemeFile, []byte(base16Scheme), 0644))
+
+			resetFlags()
+			os.Args = []string{"bubbletea-init", "theme", "import", schemeFile, "-C", projectDir, "--name", name, "--force"}
+			initialize.Initialize()
+
+			after, err := os.ReadFile(filepath.Join(projectDir, "theme", "styles.go"))
+			require.NoError(t, err)
+			assert.Equal(t, string(styles), string(after), "styles.go should be left alone")
+			assert.FileExists(t, filepath.Join(projectDir, "theme", "theme.go"))
+			assert.FileExists(t, filepath.Join(projectDir, "theme", name+"_palette.go"))
+
+			requireCompiles(t, projectDir)
+		})
+	}
+}
+
+func TestThemeImportErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		args     []string
+		project  bool
+		existing string
+		expected string
+	}{
+		{
+			name:     "unsupported format",
+			file:     "scheme.json",
+			content:  "{}",
+			project:  true,
+			expected: "unsupported scheme file 'scheme.json'",
+		},
+		{
+			name:     "incomplete base16 scheme",
+			file:     "broken.yaml",
+			content:  "scheme: Broken\nbase00: \"000000\"\n",
+			project:  true,
+			expected: "missing base01",
+		},
+		{
+			name:     "invalid color",
+			file:     "bad.toml",
+			content:  strings.Replace(alacrittyTOML, "#dc322f", "red", 1),
+			project:  true,
+			expected: "colors.normal.red: invalid color 'red'",
+		},
+		{
+			name:     "no theme package",
+			file:     "ocean.yaml",
+			content:  base16Scheme,
+			expected: "no theme package found",
+		},
+		{
+			name:     "built-in palette name",
+			file:     "ocean.yaml",
+			content:  base16Scheme,
+			args:     []string{"--name", "dracula"},
+			project:  true,
+			expected: "theme.Dracula is already declared in theme.go",
+		},
+		{
+			name:     "name of the styles type",
+			file:     "ocean.yaml",
+			content:  base16Scheme,
+			args:     []string{"--name", "styles", "--force"},
+			project:  true,
+			expected: "theme.Styles is already declared in styles.go",
+		},
+		{
+			name:     "existing palette file",
+			file:     "ocean.yaml",
+			content:  base16Scheme,
+			project:  true,
+			existing: "ocean_deep_palette.go",
+			expected: "ocean_deep_palette.go already exists. Use --force to overwrite.",
+		},
+		{
+			name:     "existing file that is not a palette",
+			file:     "ocean.yaml",
+			content:  base16Scheme,
+			args:     []string{"--name", "ocean", "--force"},
+			project:  true,
+			existing: "ocean_palette.go",
+			expected: "ocean_palette.go exists and does not hold an imported palette",
+		},
+		{
+			name:     "invalid name",
+			file:     "ocean.yaml",
+			content:  base16Scheme,
+			args:     []string{"--name", "9lives"},
+			project:  true,
+			expected: "'9lives' is not a valid palette name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"theme", "import", tt.file, "-C", "proj"}, tt.args...)
+			code, out := runExpectingExit(t, args, func(dir string) {
+				projectDir := filepath.Join(dir, "proj")
+				if tt.project {
+					os.Args = []string{"bubbletea-init", "proj"}
+					initialize.Initialize()
+					resetFlags()
+				} else {
+					require.NoError(t, os.MkdirAll(projectDir, 0755))
+					require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/proj\n"), 0644))
+				}
+				if tt.existing != "" {
+					content := "package theme\n"
+					if tt.name == "existing palette file" {
+						content += "\n// Ocean was imported with\n// bubbletea-init theme import.\n"
+					}
+					require.NoError(t, os.WriteFile(filepath.Join(projectDir, "theme", tt.existing), []byte(content), 0644))
+				}
+				require.NoError(t, os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0644))
+			})
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, tt.expected)
+			if tt.project {
+				styles, err := os.ReadFile(filepath.Join("proj", "theme", "styles.go"))
+				require.NoError(t, err)
+				assert.Contains(t, string(styles), "func NewStyles(", "The theme package should be left intact")
+			}
+		})
+	}
+}
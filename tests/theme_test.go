@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	initialize "github.com/ConstantinBalan/bubbletea-init/pkg/init"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThemePackage(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		themeDir  string
+		palette   string
+		uiFile    string
+		helpStyle bool
+	}{
+		{
+			name:      "default palette",
+			themeDir:  "theme",
+			palette:   "charm",
+			uiFile:    "main.go",
+			helpStyle: true,
+		},
+		{
+			name:      "nord with bubbles",
+			args:      []string{"--with-bubbles", "--theme", "nord"},
+			themeDir:  "theme",
+			palette:   "nord",
+			uiFile:    "main.go",
+			helpStyle: true,
+		},
+		{
+			name:     "mono without bubbles",
+			args:     []string{"--with-bubbles", "--no-deps", "--theme", "mono"},
+			themeDir: "theme",
+			palette:  "mono",
+			uiFile:   "main.go",
+		},
+		{
+			name:      "standard layout",
+			args:      []string{"--layout", "standard", "-t", "multi-screen", "--theme", "catppuccin"},
+			themeDir:  "internal/theme",
+			palette:   "catppuccin",
+			uiFile:    "internal/ui/styles.go",
+			helpStyle: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testDir, cleanup := setupTest(t)
+			defer cleanup()
+
+			envCleanup := setupTestEnv(t, testDir)
+			defer envCleanup()
+
+			os.Args = append(append([]string{"bubbletea-init", "--mod", "example.com/themed"}, tt.args...), "themed")
+			initialize.Initialize()
+
+			projectDir := filepath.Join(testDir, "themed")
+			themeDir := filepath.Join(projectDir, filepath.FromSlash(tt.themeDir))
+			for _, file := range []string{"theme.go", "styles.go", "theme_test.go"} {
+				assert.FileExists(t, filepath.Join(themeDir, file))
+			}
+
+			themeContent, err := os.ReadFile(filepath.Join(themeDir, "theme.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(themeContent), `const Default = "`+tt.palette+`"`)
+			assert.Contains(t, string(themeContent), "lipgloss.AdaptiveColor{")
+			assert.Contains(t, string(themeContent), `os.Getenv("NO_COLOR")`)
+			assert.Contains(t, string(themeContent), `os.Getenv("THEMED_THEME")`)
+
+			stylesContent, err := os.ReadFile(filepath.Join(themeDir, "styles.go"))
+			require.NoError(t, err)
+			if tt.helpStyle {
+				assert.Contains(t, string(stylesContent), "Help help.Styles")
+			} else {
+				assert.NotContains(t, string(stylesContent), "bubbles", "Expected no bubbles import without bubbles")
+			}
+
+			uiContent, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(tt.uiFile)))
+			require.NoError(t, err)
+			assert.Contains(t, string(uiContent), `"example.com/themed/`+tt.themeDir+`"`)
+			assert.Contains(t, string(uiContent), "theme.NewStyles(theme.Current())")
+			assert.NotContains(t, string(uiContent), "#7D56F4", "Expected no hard-coded colors")
+		})
+	}
+}
+
+func TestUnknownTheme(t *testing.T) {
+	code, out := runExpectingExit(t, []string{"--theme", "solarized", "proj"})
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out, "unknown theme 'solarized'")
+	assert.Contains(t, out, "dracula, nord, catppuccin, mono")
+	assert.NoDirExists(t, "proj")
+}
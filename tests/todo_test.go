@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoTemplate(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "todo"})
+	requireValidGo(t, projectDir)
+
+	for _, file := range []string{"main.go", "store.go", "delegate.go", "dialog.go", "keys.go", "main_test.go", "store_test.go", "harness_test.go"} {
+		_, err := os.Stat(filepath.Join(projectDir, file))
+		assert.NoError(t, err, "Expected %s to be generated", file)
+	}
+
+	store, err := os.ReadFile(filepath.Join(projectDir, "store.go"))
+	require.NoError(t, err)
+	for _, snippet := range []string{
+		`os.Getenv("OPTS_DATA")`,
+		`os.Getenv("XDG_DATA_HOME")`,
+		`filepath.Join(dir, "opts", "todos.json")`,
+		"os.CreateTemp(dir,",
+		"os.Rename(f.Name(), s.path)",
+	} {
+		assert.Contains(t, string(store), snippet)
+	}
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "l.DisableQuitKeybindings()", "Quitting should go through the key map to save first")
+	assert.Contains(t, string(mainContent), "l.AdditionalShortHelpKeys = keys.ShortHelp")
+	assert.Contains(t, string(mainContent), "tea.WithAltScreen()")
+}
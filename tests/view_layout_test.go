@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewLayout(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		uiDir     string
+		modelFile string
+		snippets  []string
+	}{
+		{
+			name:      "stacked",
+			args:      []string{"--view-layout", "stacked"},
+			uiDir:     ".",
+			modelFile: "main.go",
+			snippets:  []string{"m.layout = m.layout.resize(msg)", "m.layout.view(m.header(), m.footer())", "m.layout.scrollTo(m.cursor)"},
+		},
+		{
+			name:      "split",
+			args:      []string{"--view-layout", "split"},
+			uiDir:     ".",
+			modelFile: "main.go",
+			snippets:  []string{"m.layout = m.layout.resize(msg)", "m.layout.view(m.header(), m.list(), m.cursor, m.footer())", "func (m model) detail() string"},
+		},
+		{
+			name:      "stacked standard layout",
+			args:      []string{"--view-layout", "stacked", "--layout", "standard"},
+			uiDir:     "internal/ui",
+			modelFile: "model.go",
+			snippets:  []string{"m.layout = m.layout.resize(msg)", "m.layout.view(m.header(), m.footer())"},
+		},
+		{
+			name:      "split standard layout",
+			args:      []string{"--view-layout", "split", "--layout", "standard"},
+			uiDir:     "internal/ui",
+			modelFile: "model.go",
+			snippets:  []string{"m.layout.view(m.header(), m.list(), m.cursor, m.footer())", "func (m Model) detail() string"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := generateWithOptions(t, tt.args)
+			requireValidGo(t, projectDir)
+			uiDir := filepath.Join(projectDir, filepath.FromSlash(tt.uiDir))
+
+			model, err := os.ReadFile(filepath.Join(uiDir, tt.modelFile))
+			require.NoError(t, err)
+			for _, snippet := range tt.snippets {
+				assert.Contains(t, string(model), snippet)
+			}
+
+			layout, err := os.ReadFile(filepath.Join(uiDir, "layout.go"))
+			require.NoError(t, err, "Expected layout.go next to the model")
+			for _, snippet := range []string{
+				"minWidth  = 40",
+				"func (l layout) fit(header, footer string) layout",
+				"lipgloss.JoinVertical(lipgloss.Left, header",
+				"lipgloss.Place(l.width, l.height, lipgloss.Center, lipgloss.Center",
+			} {
+				assert.Contains(t, string(layout), snippet)
+			}
+			if tt.args[1] == "split" {
+				assert.Contains(t, string(layout), "lipgloss.JoinHorizontal(lipgloss.Top, left, right)")
+			}
+
+			layoutTest, err := os.ReadFile(filepath.Join(uiDir, "layout_test.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(layoutTest), "func TestTooSmall(t *testing.T)")
+			assert.Contains(t, string(layoutTest), "func TestResize(t *testing.T)")
+
+			if tt.uiDir == "." {
+				keys, err := os.ReadFile(filepath.Join(projectDir, "keys.go"))
+				require.NoError(t, err)
+				assert.Contains(t, string(keys), `key.WithKeys("up", "k")`, "The cursor needs up and down bindings")
+			}
+		})
+	}
+}
+
+func TestViewLayoutCompiles(t *testing.T) {
+	for _, args := range [][]string{
+		{"--view-layout", "split", "--mouse", "cell", "--report-focus"},
+		{"--view-layout", "stacked", "--layout", "standard", "--cli"},
+	} {
+		t.Run(args[1]+"_"+args[2], func(t *testing.T) {
+			requireCompiles(t, generateWithOptions(t, args))
+		})
+	}
+}
+
+func TestViewLayoutErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"unknown view layout", []string{"--view-layout", "grid"}, "unknown view layout 'grid'. Available view layouts: stacked, split"},
+		{"other template", []string{"--view-layout", "split", "-t", "multi-screen"}, "--view-layout only applies to the default template, not multi-screen"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := runExpectingExit(t, append(tt.args, "views"))
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, tt.expected)
+		})
+	}
+}
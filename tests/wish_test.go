@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWishTemplate(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "wish"})
+	requireValidGo(t, projectDir)
+
+	for _, file := range []string{"main.go", "server.go", "model.go", "keys.go", "model_test.go", "server_test.go", "harness_test.go"} {
+		_, err := os.Stat(filepath.Join(projectDir, file))
+		assert.NoError(t, err, "Expected %s to be generated", file)
+	}
+
+	server, err := os.ReadFile(filepath.Join(projectDir, "server.go"))
+	require.NoError(t, err)
+	for _, snippet := range []string{
+		"wish.WithHostKeyPath(cfg.hostKey)",
+		"bubbletea.Middleware(teaHandler(keys))",
+		"activeterm.Middleware()",
+		"logging.Middleware()",
+		"bubbletea.MakeRenderer(s)",
+		"return m, []tea.ProgramOption{tea.WithAltScreen()}",
+		"srv.Shutdown(shutdownCtx)",
+		`getenv("OPTS_PORT", "23234")`,
+	} {
+		assert.Contains(t, string(server), snippet)
+	}
+
+	mainContent, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mainContent), "signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)")
+	assert.Contains(t, string(mainContent), "serve(ctx, srv, l)")
+
+	serverTest, err := os.ReadFile(filepath.Join(projectDir, "server_test.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(serverTest), "gossh.FixedHostKey(hostKey)", "The test should check the generated host key")
+	assert.Contains(t, string(serverTest), `session.RequestPty("xterm-256color", 30, 100`)
+
+	goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(goMod), "github.com/charmbracelet/wish v1.3.1")
+	assert.Contains(t, string(goMod), "golang.org/x/crypto")
+}
+
+func TestWishTemplateInline(t *testing.T) {
+	projectDir := generateWithOptions(t, []string{"-t", "wish", "--inline"})
+
+	server, err := os.ReadFile(filepath.Join(projectDir, "server.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(server), "return m, nil")
+	assert.NotContains(t, string(server), "tea.WithAltScreen()")
+}
+
+func TestWishTemplateCompiles(t *testing.T) {
+	requireCompiles(t, generateWithOptions(t, []string{"-t", "wish"}))
+}
+
+func TestWishTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"cli", []string{"--cli"}, "--cli does not apply to the wish template"},
+		{"debug", []string{"--debug"}, "--debug does not apply to the wish template"},
+		{"mouse", []string{"--mouse", "cell"}, "--mouse does not apply to the wish template"},
+		{"standard layout", []string{"--layout", "standard"}, "the wish template does not support --layout standard"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := runExpectingExit(t, append(append([]string{"-t", "wish"}, tt.args...), "server"))
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, tt.expected)
+		})
+	}
+}